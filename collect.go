@@ -0,0 +1,261 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const collectsBasePath = "collects"
+const collectionsBasePath = "collections"
+
+// CollectService is an interface for interfacing with the collect endpoints
+// of the Shoplazza API. A collect links a product to a custom collection.
+type CollectService interface {
+	List(interface{}) ([]Collect, error)
+	ListWithContext(context.Context, interface{}) ([]Collect, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Collect, error)
+	GetWithContext(context.Context, ID, interface{}) (*Collect, error)
+	Create(Collect) (*Collect, error)
+	CreateWithContext(context.Context, Collect) (*Collect, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	AddProduct(ID, ID) (*Collect, error)
+	AddProductWithContext(context.Context, ID, ID) (*Collect, error)
+	RemoveProduct(ID, ID) error
+	RemoveProductWithContext(context.Context, ID, ID) error
+	Reorder(ID, []ID) error
+	ReorderWithContext(context.Context, ID, []ID) error
+}
+
+// CollectServiceOp handles communication with the collect related methods of
+// the Shoplazza API.
+type CollectServiceOp struct {
+	client *Client
+}
+
+// Collect represents a Shoplazza collect
+type Collect struct {
+	ID           ID         `json:"id,omitempty"`
+	CollectionID ID         `json:"collection_id,omitempty"`
+	ProductID    ID         `json:"product_id,omitempty"`
+	Featured     bool       `json:"featured,omitempty"`
+	Position     FlexInt    `json:"position,omitempty"`
+	SortValue    string     `json:"sort_value,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// CollectListOptions can be used for filtering collects on a List or Count
+// request.
+type CollectListOptions struct {
+	Page         int    `url:"page,omitempty"`
+	Limit        int    `url:"limit,omitempty"`
+	SinceID      ID     `url:"since_id,omitempty"`
+	CollectionID ID     `url:"collection_id,omitempty"`
+	ProductID    ID     `url:"product_id,omitempty"`
+	Fields       string `url:"fields,omitempty"`
+}
+
+// CollectResource represents the result from the collects/X endpoint
+type CollectResource struct {
+	Collect *Collect `json:"collect"`
+}
+
+// CollectsResource represents the result from the collects endpoint
+type CollectsResource struct {
+	Collects []Collect `json:"collects"`
+}
+
+// List collects
+func (s *CollectServiceOp) List(options interface{}) ([]Collect, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists collects using ctx for the request
+func (s *CollectServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Collect, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, collectsBasePath)
+	resource := new(CollectsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Collects, err
+}
+
+// Count collects
+func (s *CollectServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts collects using ctx for the request
+func (s *CollectServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, collectsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual collect
+func (s *CollectServiceOp) Get(collectID ID, options interface{}) (*Collect, error) {
+	return s.GetWithContext(context.Background(), collectID, options)
+}
+
+// GetWithContext gets an individual collect using ctx for the request
+func (s *CollectServiceOp) GetWithContext(ctx context.Context, collectID ID, options interface{}) (*Collect, error) {
+	if err := requireIDs(collectID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, collectsBasePath, collectID.escape())
+	resource := new(CollectResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Collect, err
+}
+
+// Create a new collect
+func (s *CollectServiceOp) Create(collect Collect) (*Collect, error) {
+	return s.CreateWithContext(context.Background(), collect)
+}
+
+// CreateWithContext creates a new collect using ctx for the request
+func (s *CollectServiceOp) CreateWithContext(ctx context.Context, collect Collect) (*Collect, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, collectsBasePath)
+	wrappedData := CollectResource{Collect: &collect}
+	resource := new(CollectResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Collect, err
+}
+
+// Delete an existing collect
+func (s *CollectServiceOp) Delete(collectID ID) error {
+	return s.DeleteWithContext(context.Background(), collectID)
+}
+
+// DeleteWithContext deletes an existing collect using ctx for the request
+func (s *CollectServiceOp) DeleteWithContext(ctx context.Context, collectID ID) error {
+	if err := requireIDs(collectID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, collectsBasePath, collectID.escape()))
+}
+
+// AddProduct adds a product to a custom collection
+func (s *CollectServiceOp) AddProduct(collectionID, productID ID) (*Collect, error) {
+	return s.AddProductWithContext(context.Background(), collectionID, productID)
+}
+
+// AddProductWithContext adds a product to a custom collection using ctx for
+// the request
+func (s *CollectServiceOp) AddProductWithContext(ctx context.Context, collectionID, productID ID) (*Collect, error) {
+	if err := requireIDs(collectionID, productID); err != nil {
+		return nil, err
+	}
+	return s.CreateWithContext(ctx, Collect{CollectionID: collectionID, ProductID: productID})
+}
+
+// RemoveProduct removes a product from a custom collection by deleting the
+// collect linking them. Removing a product that is not in the collection is
+// not an error.
+func (s *CollectServiceOp) RemoveProduct(collectionID, productID ID) error {
+	return s.RemoveProductWithContext(context.Background(), collectionID, productID)
+}
+
+// RemoveProductWithContext removes a product from a custom collection using
+// ctx for the requests
+func (s *CollectServiceOp) RemoveProductWithContext(ctx context.Context, collectionID, productID ID) error {
+	if err := requireIDs(collectionID, productID); err != nil {
+		return err
+	}
+	collects, err := s.ListWithContext(ctx, CollectListOptions{CollectionID: collectionID, ProductID: productID})
+	if err != nil {
+		return err
+	}
+	for _, collect := range collects {
+		if collect.CollectionID != collectionID || collect.ProductID != productID {
+			continue
+		}
+		if err := s.DeleteWithContext(ctx, collect.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reorder sorts the products of a custom collection manually, in the order
+// of productIDs. Products of the collection missing from productIDs are
+// placed after the given ones.
+func (s *CollectServiceOp) Reorder(collectionID ID, productIDs []ID) error {
+	return s.ReorderWithContext(context.Background(), collectionID, productIDs)
+}
+
+// ReorderWithContext reorders the products of a custom collection using ctx
+// for the requests
+func (s *CollectServiceOp) ReorderWithContext(ctx context.Context, collectionID ID, productIDs []ID) error {
+	if err := requireIDs(collectionID); err != nil {
+		return err
+	}
+	it := newIterator(ctx, 1, "", func(ctx context.Context, page int, sinceID ID) ([]Collect, error) {
+		return s.ListWithContext(ctx, CollectListOptions{CollectionID: collectionID, Page: page, Limit: 250})
+	}, func(collect Collect) ID { return collect.ID })
+	existing, err := collect(it)
+	if err != nil {
+		return err
+	}
+
+	byProduct := make(map[ID]Collect, len(existing))
+	for _, c := range existing {
+		byProduct[c.ProductID] = c
+	}
+
+	collects := make([]Collect, 0, len(existing))
+	placed := make(map[ID]bool, len(productIDs))
+	for _, productID := range productIDs {
+		c, ok := byProduct[productID]
+		if !ok {
+			return fmt.Errorf("goshoplazza: product %s is not in collection %s", productID, collectionID)
+		}
+		if placed[productID] {
+			continue
+		}
+		placed[productID] = true
+		collects = append(collects, Collect{ID: c.ID, Position: FlexInt(len(collects) + 1)})
+	}
+	for _, c := range existing {
+		if !placed[c.ProductID] {
+			placed[c.ProductID] = true
+			collects = append(collects, Collect{ID: c.ID, Position: FlexInt(len(collects) + 1)})
+		}
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customCollectionsBasePath, collectionID.escape())
+	wrappedData := CustomCollectionResource{CustomCollection: &CustomCollection{
+		ID:        collectionID,
+		SortOrder: CollectionSortManual,
+		Collects:  collects,
+	}}
+	return s.client.PutWithContext(ctx, path, wrappedData, nil)
+}
+
+// listCollectionProducts lists the products of a custom or smart collection
+func (c *Client) listCollectionProducts(ctx context.Context, collectionID ID, options interface{}) ([]Product, error) {
+	if err := requireIDs(collectionID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/products", c.pathPrefix, collectionsBasePath, collectionID.escape())
+	resource := new(ProductsResource)
+	err := c.GetWithContext(ctx, path, resource, options)
+	return resource.Products, err
+}
+
+// iterCollectionProducts returns an iterator over the products of a custom
+// or smart collection
+func (c *Client) iterCollectionProducts(ctx context.Context, collectionID ID, options *ListOptions) *Iterator[Product] {
+	opts := ListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	fetch := func(ctx context.Context, page int, sinceID ID) ([]Product, error) {
+		pageOpts := opts
+		pageOpts.Page, pageOpts.SinceID = page, sinceID
+		return c.listCollectionProducts(ctx, collectionID, &pageOpts)
+	}
+	id := func(product Product) ID { return product.ID }
+	return newIterator(ctx, opts.Page, opts.SinceID, fetch, id)
+}
@@ -0,0 +1,145 @@
+package goshoplazza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// collectsHandler serves the collects of collection c1, linking products p1
+// to p3, one per page, and records the other requests. Like some servers it
+// ignores the product_id filter.
+func collectsHandler(requests *[]string, written *CustomCollectionResource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/openapi/collects" {
+			q := r.URL.Query()
+			if q.Get("collection_id") != "c1" {
+				w.Write([]byte(`{"collects":[]}`))
+				return
+			}
+			page := q.Get("page")
+			if page == "" {
+				page = "1"
+			}
+			switch page {
+			case "1", "2", "3":
+				fmt.Fprintf(w, `{"collects":[{"id":"k%s","collection_id":"c1","product_id":"p%s"}]}`, page, page)
+			default:
+				w.Write([]byte(`{"collects":[]}`))
+			}
+			return
+		}
+
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		if written != nil && r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(written)
+		}
+		w.Write([]byte(`{}`))
+	}
+}
+
+func TestCollectReorder(t *testing.T) {
+	var requests []string
+	var written CustomCollectionResource
+	client := newTestClient(t, collectsHandler(&requests, &written))
+
+	if err := client.Collect.Reorder("c1", []ID{"p3", "p1"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "PUT /openapi/custom_collections/c1" {
+		t.Fatalf("requests = %v, want a single PUT of the collection", requests)
+	}
+
+	collection := written.CustomCollection
+	if collection == nil || collection.SortOrder != CollectionSortManual {
+		t.Fatalf("written %+v, want a manually sorted collection", collection)
+	}
+	var got []string
+	for _, c := range collection.Collects {
+		got = append(got, fmt.Sprintf("%s@%d", c.ID, c.Position))
+	}
+	// The collects of all pages are placed, the missing p2 last
+	if want := "k3@1 k1@2 k2@3"; strings.Join(got, " ") != want {
+		t.Errorf("collects = %v, want %s", got, want)
+	}
+}
+
+func TestCollectReorderErrors(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, collectsHandler(&requests, nil))
+
+	if err := client.Collect.Reorder("c1", []ID{"p1", "p9"}); err == nil || !strings.Contains(err.Error(), "p9") {
+		t.Errorf("Reorder with a product not in the collection error = %v, want one naming p9", err)
+	}
+	if err := client.Collect.ReorderWithContext(context.Background(), "", []ID{"p1"}); !errors.Is(err, ErrEmptyID) {
+		t.Errorf("Reorder without a collection error = %v, want %v", err, ErrEmptyID)
+	}
+	if len(requests) != 0 {
+		t.Errorf("requests = %v, want nothing written", requests)
+	}
+}
+
+func TestCollectRemoveProduct(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, collectsHandler(&requests, nil))
+
+	// The server ignores the product filter, only the matching collect of the
+	// first page is deleted
+	if err := client.Collect.RemoveProduct("c1", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "DELETE /openapi/collects/k1" {
+		t.Errorf("requests = %v, want the collect of p1 deleted", requests)
+	}
+
+	requests = nil
+	if err := client.Collect.RemoveProduct("c1", "p9"); err != nil {
+		t.Errorf("RemoveProduct of a product not in the collection error = %v, want nil", err)
+	}
+	if err := client.Collect.RemoveProduct("c1", ""); !errors.Is(err, ErrEmptyID) {
+		t.Errorf("RemoveProduct without a product error = %v, want %v", err, ErrEmptyID)
+	}
+	if len(requests) != 0 {
+		t.Errorf("requests = %v, want nothing deleted", requests)
+	}
+}
+
+func TestCollectionIterProducts(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openapi/collections/c1/products" {
+			t.Errorf("path = %s, want /openapi/collections/c1/products", r.URL.Path)
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch page {
+		case "2":
+			w.Write([]byte(`{"products":[{"id":"1"},{"id":"2"}]}`))
+		case "3":
+			w.Write([]byte(`{"products":[{"id":"3"}]}`))
+		default:
+			w.Write([]byte(`{"products":[]}`))
+		}
+	}))
+
+	it := client.CustomCollection.IterProducts(context.Background(), "c1", &ListOptions{Page: 2, Limit: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, string(it.Value().ID))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "1,2,3" || strings.Join(pages, ",") != "2,3,4" {
+		t.Errorf("ids %v from pages %v, want 1,2,3 from pages 2,3,4", ids, pages)
+	}
+
+	it = client.SmartCollection.IterProducts(context.Background(), "", nil)
+	if it.Next() || !errors.Is(it.Err(), ErrEmptyID) {
+		t.Errorf("IterProducts without a collection error = %v, want %v", it.Err(), ErrEmptyID)
+	}
+}
@@ -0,0 +1,202 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const customCollectionsBasePath = "custom_collections"
+
+// Sort orders of the products of a collection
+const (
+	CollectionSortAlphaAsc    = "alpha-asc"
+	CollectionSortAlphaDesc   = "alpha-desc"
+	CollectionSortBestSelling = "best-selling"
+	CollectionSortCreated     = "created"
+	CollectionSortCreatedDesc = "created-desc"
+	CollectionSortManual      = "manual"
+	CollectionSortPriceAsc    = "price-asc"
+	CollectionSortPriceDesc   = "price-desc"
+)
+
+// CustomCollectionService is an interface for interfacing with the custom
+// collection endpoints of the Shoplazza API.
+type CustomCollectionService interface {
+	List(interface{}) ([]CustomCollection, error)
+	ListWithContext(context.Context, interface{}) ([]CustomCollection, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*CustomCollection, error)
+	GetWithContext(context.Context, ID, interface{}) (*CustomCollection, error)
+	Create(CustomCollection) (*CustomCollection, error)
+	CreateWithContext(context.Context, CustomCollection) (*CustomCollection, error)
+	Update(CustomCollection) (*CustomCollection, error)
+	UpdateWithContext(context.Context, CustomCollection) (*CustomCollection, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	ListProducts(ID, interface{}) ([]Product, error)
+	ListProductsWithContext(context.Context, ID, interface{}) ([]Product, error)
+	IterProducts(context.Context, ID, *ListOptions) *Iterator[Product]
+}
+
+// CustomCollectionServiceOp handles communication with the custom collection
+// related methods of the Shoplazza API.
+type CustomCollectionServiceOp struct {
+	client *Client
+}
+
+// CustomCollection represents a Shoplazza collection whose products are
+// picked manually through collects.
+type CustomCollection struct {
+	ID             ID               `json:"id,omitempty"`
+	Handle         string           `json:"handle,omitempty"`
+	Title          string           `json:"title,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	SortOrder      string           `json:"sort_order,omitempty"`
+	TemplateSuffix string           `json:"template_suffix,omitempty"`
+	Image          *CollectionImage `json:"image,omitempty"`
+	Published      bool             `json:"published,omitempty"`
+	PublishedAt    *time.Time       `json:"published_at,omitempty"`
+	PublishedScope string           `json:"published_scope,omitempty"`
+	Collects       []Collect        `json:"collects,omitempty"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+// CollectionImage is the image of a custom or smart collection
+type CollectionImage struct {
+	Src       string     `json:"src,omitempty"`
+	Alt       string     `json:"alt,omitempty"`
+	Width     FlexInt    `json:"width,omitempty"`
+	Height    FlexInt    `json:"height,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// CollectionListOptions can be used for filtering custom and smart
+// collections on a List or Count request.
+type CollectionListOptions struct {
+	Page            int       `url:"page,omitempty"`
+	Limit           int       `url:"limit,omitempty"`
+	SinceID         ID        `url:"since_id,omitempty"`
+	IDs             []ID      `url:"ids,omitempty,comma"`
+	Title           string    `url:"title,omitempty"`
+	Handle          string    `url:"handle,omitempty"`
+	ProductID       ID        `url:"product_id,omitempty"`
+	PublishedStatus string    `url:"published_status,omitempty"`
+	UpdatedAtMin    time.Time `url:"updated_at_min,omitempty"`
+	UpdatedAtMax    time.Time `url:"updated_at_max,omitempty"`
+	Fields          string    `url:"fields,omitempty"`
+}
+
+// CustomCollectionResource represents the result from the custom_collections/X endpoint
+type CustomCollectionResource struct {
+	CustomCollection *CustomCollection `json:"custom_collection"`
+}
+
+// CustomCollectionsResource represents the result from the custom_collections endpoint
+type CustomCollectionsResource struct {
+	CustomCollections []CustomCollection `json:"custom_collections"`
+}
+
+// List custom collections
+func (s *CustomCollectionServiceOp) List(options interface{}) ([]CustomCollection, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists custom collections using ctx for the request
+func (s *CustomCollectionServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]CustomCollection, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, customCollectionsBasePath)
+	resource := new(CustomCollectionsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.CustomCollections, err
+}
+
+// Count custom collections
+func (s *CustomCollectionServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts custom collections using ctx for the request
+func (s *CustomCollectionServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, customCollectionsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual custom collection
+func (s *CustomCollectionServiceOp) Get(collectionID ID, options interface{}) (*CustomCollection, error) {
+	return s.GetWithContext(context.Background(), collectionID, options)
+}
+
+// GetWithContext gets an individual custom collection using ctx for the request
+func (s *CustomCollectionServiceOp) GetWithContext(ctx context.Context, collectionID ID, options interface{}) (*CustomCollection, error) {
+	if err := requireIDs(collectionID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customCollectionsBasePath, collectionID.escape())
+	resource := new(CustomCollectionResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.CustomCollection, err
+}
+
+// Create a new custom collection. Products may be added right away by
+// setting the ProductID of its Collects.
+func (s *CustomCollectionServiceOp) Create(collection CustomCollection) (*CustomCollection, error) {
+	return s.CreateWithContext(context.Background(), collection)
+}
+
+// CreateWithContext creates a new custom collection using ctx for the request
+func (s *CustomCollectionServiceOp) CreateWithContext(ctx context.Context, collection CustomCollection) (*CustomCollection, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, customCollectionsBasePath)
+	wrappedData := CustomCollectionResource{CustomCollection: &collection}
+	resource := new(CustomCollectionResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.CustomCollection, err
+}
+
+// Update an existing custom collection
+func (s *CustomCollectionServiceOp) Update(collection CustomCollection) (*CustomCollection, error) {
+	return s.UpdateWithContext(context.Background(), collection)
+}
+
+// UpdateWithContext updates an existing custom collection using ctx for the request
+func (s *CustomCollectionServiceOp) UpdateWithContext(ctx context.Context, collection CustomCollection) (*CustomCollection, error) {
+	if err := requireIDs(collection.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customCollectionsBasePath, collection.ID.escape())
+	wrappedData := CustomCollectionResource{CustomCollection: &collection}
+	resource := new(CustomCollectionResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.CustomCollection, err
+}
+
+// Delete an existing custom collection
+func (s *CustomCollectionServiceOp) Delete(collectionID ID) error {
+	return s.DeleteWithContext(context.Background(), collectionID)
+}
+
+// DeleteWithContext deletes an existing custom collection using ctx for the request
+func (s *CustomCollectionServiceOp) DeleteWithContext(ctx context.Context, collectionID ID) error {
+	if err := requireIDs(collectionID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customCollectionsBasePath, collectionID.escape()))
+}
+
+// ListProducts lists the products of a custom collection
+func (s *CustomCollectionServiceOp) ListProducts(collectionID ID, options interface{}) ([]Product, error) {
+	return s.ListProductsWithContext(context.Background(), collectionID, options)
+}
+
+// ListProductsWithContext lists the products of a custom collection using
+// ctx for the request
+func (s *CustomCollectionServiceOp) ListProductsWithContext(ctx context.Context, collectionID ID, options interface{}) ([]Product, error) {
+	return s.client.listCollectionProducts(ctx, collectionID, options)
+}
+
+// IterProducts returns an iterator over the products of all pages of a
+// custom collection
+func (s *CustomCollectionServiceOp) IterProducts(ctx context.Context, collectionID ID, options *ListOptions) *Iterator[Product] {
+	return s.client.iterCollectionProducts(ctx, collectionID, options)
+}
@@ -0,0 +1,194 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const customersBasePath = "customers"
+
+// CustomerService is an interface for interfacing with the customers endpoints
+// of the Shoplazza API.
+type CustomerService interface {
+	List(interface{}) ([]Customer, error)
+	ListWithContext(context.Context, interface{}) ([]Customer, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Customer, error)
+	GetWithContext(context.Context, ID, interface{}) (*Customer, error)
+	Search(interface{}) ([]Customer, error)
+	SearchWithContext(context.Context, interface{}) ([]Customer, error)
+	Create(Customer) (*Customer, error)
+	CreateWithContext(context.Context, Customer) (*Customer, error)
+	Update(Customer) (*Customer, error)
+	UpdateWithContext(context.Context, Customer) (*Customer, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	ListOrders(ID, interface{}) ([]Order, error)
+	ListOrdersWithContext(context.Context, ID, interface{}) ([]Order, error)
+}
+
+// CustomerServiceOp handles communication with the customer related methods of
+// the Shoplazza API.
+type CustomerServiceOp struct {
+	client *Client
+}
+
+// Customer represents a Shoplazza customer
+type Customer struct {
+	ID               ID                 `json:"id,omitempty"`
+	Email            string             `json:"email,omitempty"`
+	Phone            string             `json:"phone,omitempty"`
+	FirstName        string             `json:"first_name,omitempty"`
+	LastName         string             `json:"last_name,omitempty"`
+	State            string             `json:"state,omitempty"`
+	Note             string             `json:"note,omitempty"`
+	Tags             string             `json:"tags,omitempty"`
+	AcceptsMarketing bool               `json:"accepts_marketing,omitempty"`
+	VerifiedEmail    bool               `json:"verified_email,omitempty"`
+	OrdersCount      int                `json:"orders_count,omitempty"`
+	TotalSpent       *decimal.Decimal   `json:"total_spent,omitempty"`
+	LastOrderId      ID                 `json:"last_order_id,omitempty"`
+	LastOrderName    string             `json:"last_order_name,omitempty"`
+	Currency         string             `json:"currency,omitempty"`
+	DefaultAddress   *CustomerAddress   `json:"default_address,omitempty"`
+	Addresses        []*CustomerAddress `json:"addresses,omitempty"`
+	CreatedAt        *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time         `json:"updated_at,omitempty"`
+}
+
+// Represents the result from the customers/X endpoint
+type CustomerResource struct {
+	Customer *Customer `json:"customer"`
+}
+
+// Represents the result from the customers endpoint
+type CustomersResource struct {
+	Customers []Customer `json:"customers"`
+}
+
+// Represents the options available when searching for a customer
+type CustomerSearchOptions struct {
+	Page   int    `url:"page,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+	Fields string `url:"fields,omitempty"`
+	Order  string `url:"order,omitempty"`
+	Query  string `url:"query,omitempty"`
+}
+
+// List customers
+func (s *CustomerServiceOp) List(options interface{}) ([]Customer, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists customers using ctx for the request
+func (s *CustomerServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Customer, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, customersBasePath)
+	resource := new(CustomersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Customers, err
+}
+
+// Count customers
+func (s *CustomerServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts customers using ctx for the request
+func (s *CustomerServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, customersBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get customer
+func (s *CustomerServiceOp) Get(customerID ID, options interface{}) (*Customer, error) {
+	return s.GetWithContext(context.Background(), customerID, options)
+}
+
+// GetWithContext gets a customer using ctx for the request
+func (s *CustomerServiceOp) GetWithContext(ctx context.Context, customerID ID, options interface{}) (*Customer, error) {
+	if err := requireIDs(customerID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customersBasePath, customerID.escape())
+	resource := new(CustomerResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Customer, err
+}
+
+// Search customers, see CustomerSearchOptions for the search query
+func (s *CustomerServiceOp) Search(options interface{}) ([]Customer, error) {
+	return s.SearchWithContext(context.Background(), options)
+}
+
+// SearchWithContext searches customers using ctx for the request
+func (s *CustomerServiceOp) SearchWithContext(ctx context.Context, options interface{}) ([]Customer, error) {
+	path := fmt.Sprintf("%s/%s/search", s.client.pathPrefix, customersBasePath)
+	resource := new(CustomersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Customers, err
+}
+
+// Create a new customer
+func (s *CustomerServiceOp) Create(customer Customer) (*Customer, error) {
+	return s.CreateWithContext(context.Background(), customer)
+}
+
+// CreateWithContext creates a new customer using ctx for the request
+func (s *CustomerServiceOp) CreateWithContext(ctx context.Context, customer Customer) (*Customer, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, customersBasePath)
+	wrappedData := CustomerResource{Customer: &customer}
+	resource := new(CustomerResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Customer, err
+}
+
+// Update an existing customer
+func (s *CustomerServiceOp) Update(customer Customer) (*Customer, error) {
+	return s.UpdateWithContext(context.Background(), customer)
+}
+
+// UpdateWithContext updates an existing customer using ctx for the request
+func (s *CustomerServiceOp) UpdateWithContext(ctx context.Context, customer Customer) (*Customer, error) {
+	if err := requireIDs(customer.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customersBasePath, customer.ID.escape())
+	wrappedData := CustomerResource{Customer: &customer}
+	resource := new(CustomerResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Customer, err
+}
+
+// Delete an existing customer
+func (s *CustomerServiceOp) Delete(customerID ID) error {
+	return s.DeleteWithContext(context.Background(), customerID)
+}
+
+// DeleteWithContext deletes an existing customer using ctx for the request
+func (s *CustomerServiceOp) DeleteWithContext(ctx context.Context, customerID ID) error {
+	if err := requireIDs(customerID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, customersBasePath, customerID.escape()))
+}
+
+// ListOrders retrieves all orders from a customer
+func (s *CustomerServiceOp) ListOrders(customerID ID, options interface{}) ([]Order, error) {
+	return s.ListOrdersWithContext(context.Background(), customerID, options)
+}
+
+// ListOrdersWithContext retrieves all orders from a customer using ctx for
+// the request
+func (s *CustomerServiceOp) ListOrdersWithContext(ctx context.Context, customerID ID, options interface{}) ([]Order, error) {
+	if err := requireIDs(customerID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/%s", s.client.pathPrefix, customersBasePath, customerID.escape(), ordersBasePath)
+	resource := new(OrdersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Orders, err
+}
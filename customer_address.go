@@ -0,0 +1,159 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+)
+
+// CustomerAddressService is an interface for interfacing with the customer address endpoints
+// of the Shoplazza API.
+type CustomerAddressService interface {
+	List(ID, interface{}) ([]CustomerAddress, error)
+	ListWithContext(context.Context, ID, interface{}) ([]CustomerAddress, error)
+	Get(ID, ID, interface{}) (*CustomerAddress, error)
+	GetWithContext(context.Context, ID, ID, interface{}) (*CustomerAddress, error)
+	Create(ID, CustomerAddress) (*CustomerAddress, error)
+	CreateWithContext(context.Context, ID, CustomerAddress) (*CustomerAddress, error)
+	Update(ID, CustomerAddress) (*CustomerAddress, error)
+	UpdateWithContext(context.Context, ID, CustomerAddress) (*CustomerAddress, error)
+	Delete(ID, ID) error
+	DeleteWithContext(context.Context, ID, ID) error
+	SetDefault(ID, ID) (*CustomerAddress, error)
+	SetDefaultWithContext(context.Context, ID, ID) (*CustomerAddress, error)
+}
+
+// CustomerAddressServiceOp handles communication with the customer address related methods of
+// the Shoplazza API.
+type CustomerAddressServiceOp struct {
+	client *Client
+}
+
+// CustomerAddress represents a Shoplazza customer address
+type CustomerAddress struct {
+	ID           ID     `json:"id,omitempty"`
+	CustomerID   ID     `json:"customer_id,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	Company      string `json:"company,omitempty"`
+	Address1     string `json:"address1,omitempty"`
+	Address2     string `json:"address2,omitempty"`
+	City         string `json:"city,omitempty"`
+	Province     string `json:"province,omitempty"`
+	Country      string `json:"country,omitempty"`
+	Zip          string `json:"zip,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ProvinceCode string `json:"province_code,omitempty"`
+	CountryCode  string `json:"country_code,omitempty"`
+	CountryName  string `json:"country_name,omitempty"`
+	Default      bool   `json:"default,omitempty"`
+}
+
+// CustomerAddressResource represents the result from the addresses/X endpoint
+type CustomerAddressResource struct {
+	Address *CustomerAddress `json:"customer_address"`
+}
+
+// CustomerAddressesResource represents the result from the customers/X/addresses endpoint
+type CustomerAddressesResource struct {
+	Addresses []CustomerAddress `json:"addresses"`
+}
+
+// List addresses
+func (s *CustomerAddressServiceOp) List(customerID ID, options interface{}) ([]CustomerAddress, error) {
+	return s.ListWithContext(context.Background(), customerID, options)
+}
+
+// ListWithContext lists addresses using ctx for the request
+func (s *CustomerAddressServiceOp) ListWithContext(ctx context.Context, customerID ID, options interface{}) ([]CustomerAddress, error) {
+	if err := requireIDs(customerID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/addresses", s.client.pathPrefix, customersBasePath, customerID.escape())
+	resource := new(CustomerAddressesResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Addresses, err
+}
+
+// Get address
+func (s *CustomerAddressServiceOp) Get(customerID, addressID ID, options interface{}) (*CustomerAddress, error) {
+	return s.GetWithContext(context.Background(), customerID, addressID, options)
+}
+
+// GetWithContext gets an address using ctx for the request
+func (s *CustomerAddressServiceOp) GetWithContext(ctx context.Context, customerID, addressID ID, options interface{}) (*CustomerAddress, error) {
+	if err := requireIDs(customerID, addressID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/addresses/%s", s.client.pathPrefix, customersBasePath, customerID.escape(), addressID.escape())
+	resource := new(CustomerAddressResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Address, err
+}
+
+// Create a new address for given customer
+func (s *CustomerAddressServiceOp) Create(customerID ID, address CustomerAddress) (*CustomerAddress, error) {
+	return s.CreateWithContext(context.Background(), customerID, address)
+}
+
+// CreateWithContext creates a new address for given customer using ctx for
+// the request
+func (s *CustomerAddressServiceOp) CreateWithContext(ctx context.Context, customerID ID, address CustomerAddress) (*CustomerAddress, error) {
+	if err := requireIDs(customerID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/addresses", s.client.pathPrefix, customersBasePath, customerID.escape())
+	wrappedData := CustomerAddressResource{Address: &address}
+	resource := new(CustomerAddressResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Address, err
+}
+
+// Update an existing address for given customer
+func (s *CustomerAddressServiceOp) Update(customerID ID, address CustomerAddress) (*CustomerAddress, error) {
+	return s.UpdateWithContext(context.Background(), customerID, address)
+}
+
+// UpdateWithContext updates an existing address for given customer using
+// ctx for the request
+func (s *CustomerAddressServiceOp) UpdateWithContext(ctx context.Context, customerID ID, address CustomerAddress) (*CustomerAddress, error) {
+	if err := requireIDs(customerID, address.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/addresses/%s", s.client.pathPrefix, customersBasePath, customerID.escape(), address.ID.escape())
+	wrappedData := CustomerAddressResource{Address: &address}
+	resource := new(CustomerAddressResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Address, err
+}
+
+// Delete an existing address for given customer
+func (s *CustomerAddressServiceOp) Delete(customerID, addressID ID) error {
+	return s.DeleteWithContext(context.Background(), customerID, addressID)
+}
+
+// DeleteWithContext deletes an existing address for given customer using
+// ctx for the request
+func (s *CustomerAddressServiceOp) DeleteWithContext(ctx context.Context, customerID, addressID ID) error {
+	if err := requireIDs(customerID, addressID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s/addresses/%s", s.client.pathPrefix, customersBasePath, customerID.escape(), addressID.escape()))
+}
+
+// SetDefault makes an existing address the default address of given customer
+func (s *CustomerAddressServiceOp) SetDefault(customerID, addressID ID) (*CustomerAddress, error) {
+	return s.SetDefaultWithContext(context.Background(), customerID, addressID)
+}
+
+// SetDefaultWithContext makes an existing address the default address of
+// given customer using ctx for the request
+func (s *CustomerAddressServiceOp) SetDefaultWithContext(ctx context.Context, customerID, addressID ID) (*CustomerAddress, error) {
+	if err := requireIDs(customerID, addressID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/addresses/%s/default", s.client.pathPrefix, customersBasePath, customerID.escape(), addressID.escape())
+	resource := new(CustomerAddressResource)
+	err := s.client.PutWithContext(ctx, path, nil, resource)
+	return resource.Address, err
+}
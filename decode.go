@@ -0,0 +1,346 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DecodingMode controls how Do decodes response bodies
+type DecodingMode int
+
+const (
+	// DecodeStandard decodes responses like encoding/json, ignoring unknown
+	// fields and failing on fields of an unexpected type.
+	DecodeStandard DecodingMode = iota
+
+	// DecodeLenient accepts numbers sent as strings and strings sent as
+	// numbers or booleans for the fields of the response type, e.g. an image
+	// width of both 220 and "220". An empty string decodes to zero.
+	DecodeLenient
+
+	// DecodeStrict fails with a ResponseDecodingError listing the fields of
+	// the response that the response type does not know. The response is
+	// decoded nonetheless, so the error may be logged and ignored to
+	// monitor changes of the API.
+	DecodeStrict
+)
+
+// WithDecodingMode sets how the client decodes response bodies, the
+// default is DecodeStandard.
+func WithDecodingMode(mode DecodingMode) Option {
+	return func(c *Client) {
+		c.decodingMode = mode
+	}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// decode decodes the body of resp into v according to the decoding mode of
+// the client. Decoding errors are returned as ResponseDecodingError.
+func (c *Client) decode(resp *http.Response, v interface{}) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	decodingErr := ResponseDecodingError{
+		Body:   body,
+		Status: resp.StatusCode,
+	}
+	if resp.Request != nil {
+		decodingErr.Method = resp.Request.Method
+		decodingErr.Path = resp.Request.URL.Path
+	}
+
+	decoded := body
+	err = json.Unmarshal(body, v)
+
+	var typeErr *json.UnmarshalTypeError
+	if c.decodingMode == DecodeLenient && errors.As(err, &typeErr) {
+		var normalized []byte
+		normalized, err = normalizeJSON(body, reflect.TypeOf(v), nil)
+		if err == nil {
+			decoded = normalized
+			err = json.Unmarshal(normalized, v)
+		}
+	}
+	if err != nil {
+		decodingErr.Message = err.Error()
+		decodingErr.JSONPath = jsonErrorPath(decoded, reflect.TypeOf(v), err)
+		return decodingErr
+	}
+
+	if c.decodingMode == DecodeStrict {
+		var unknown []string
+		if _, err := normalizeJSON(body, reflect.TypeOf(v), &unknown); err != nil {
+			decodingErr.Message = err.Error()
+			return decodingErr
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			decodingErr.Message = fmt.Sprintf("json: unknown fields %s", strings.Join(unknown, ", "))
+			decodingErr.JSONPath = unknown[0]
+			decodingErr.UnknownFields = unknown
+			return decodingErr
+		}
+	}
+
+	return nil
+}
+
+// jsonErrorPath returns the path of the JSON value of data that err
+// occurred at when decoding it into t, e.g. "product.images[0].width", if
+// known. encoding/json reports the fields of type errors without the array
+// indexes, and errors of custom unmarshalers without any path, so the value
+// is located by decoding the values of data one by one.
+func jsonErrorPath(data []byte, t reflect.Type, err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("offset %d", syntaxErr.Offset)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return ""
+	}
+	path, _ := findJSONError(value, t, "")
+	return path
+}
+
+// findJSONError returns the path of the first value, in key order, that
+// does not decode into its field of type t
+func findJSONError(value interface{}, t reflect.Type, path string) (string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if value == nil {
+		return "", false
+	}
+
+	ptr := reflect.PtrTo(t)
+	if !ptr.Implements(jsonUnmarshalerType) && !ptr.Implements(textUnmarshalerType) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			switch t.Kind() {
+			case reflect.Struct:
+				fields := jsonFields(t)
+				for _, key := range sortedKeys(value) {
+					field, ok := lookupJSONField(fields, key)
+					if !ok {
+						continue
+					}
+					if found, ok := findJSONError(value[key], field, joinJSONPath(path, key)); ok {
+						return found, true
+					}
+				}
+				return "", false
+			case reflect.Map:
+				for _, key := range sortedKeys(value) {
+					if found, ok := findJSONError(value[key], t.Elem(), joinJSONPath(path, key)); ok {
+						return found, true
+					}
+				}
+				return "", false
+			}
+		case []interface{}:
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+				for i, elem := range value {
+					if found, ok := findJSONError(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); ok {
+						return found, true
+					}
+				}
+				return "", false
+			}
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	if err := json.Unmarshal(data, reflect.New(t).Interface()); err != nil {
+		return path, true
+	}
+	return "", false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeJSON walks the JSON document data along the type t it is decoded
+// into. Unless unknown is nil, the paths of the object keys t has no field
+// for are appended to it, otherwise scalars are converted to the kind of
+// their field and the converted document is returned.
+func normalizeJSON(data []byte, t reflect.Type, unknown *[]string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	w := jsonWalker{unknown: unknown}
+	value = w.walk(value, t, "")
+	if unknown != nil {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+type jsonWalker struct {
+	unknown *[]string
+}
+
+func (w *jsonWalker) walk(value interface{}, t reflect.Type, path string) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types decoding themselves, e.g. ID or time.Time, are left alone
+	ptr := reflect.PtrTo(t)
+	if ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType) {
+		return value
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, elem := range value {
+				field, ok := lookupJSONField(fields, key)
+				if !ok {
+					if w.unknown != nil {
+						*w.unknown = append(*w.unknown, joinJSONPath(path, key))
+					}
+					continue
+				}
+				value[key] = w.walk(elem, field, joinJSONPath(path, key))
+			}
+		case reflect.Map:
+			for key, elem := range value {
+				value[key] = w.walk(elem, t.Elem(), joinJSONPath(path, key))
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, elem := range value {
+				value[i] = w.walk(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case string:
+		if w.unknown == nil {
+			return convertString(value, t)
+		}
+	case json.Number:
+		if w.unknown == nil && t.Kind() == reflect.String {
+			return value.String()
+		}
+	case bool:
+		if w.unknown == nil && t.Kind() == reflect.String {
+			return strconv.FormatBool(value)
+		}
+	}
+	return value
+}
+
+// convertString converts s to the kind of t, or returns s if it does not
+// represent a value of that kind.
+func convertString(s string, t reflect.Type) interface{} {
+	s = strings.TrimSpace(s)
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if s == "" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return json.Number(s)
+		}
+	case reflect.Bool:
+		if s == "" {
+			return nil
+		}
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+// jsonFields returns the types of the fields of struct type t by their JSON
+// name, including the fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(fieldType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupJSONField finds the field of key like encoding/json, preferring an
+// exact match over a case-insensitive one.
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,89 @@
+package goshoplazza
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type decodeItem struct {
+	ID    ID      `json:"id"`
+	Title string  `json:"title"`
+	Width int     `json:"width"`
+	Count FlexInt `json:"count"`
+}
+
+type decodeProduct struct {
+	Product struct {
+		Images   []decodeItem `json:"images"`
+		Variants []decodeItem `json:"variants"`
+	} `json:"product"`
+}
+
+func TestDecodingModes(t *testing.T) {
+	cases := []struct {
+		name        string
+		mode        DecodingMode
+		body        string
+		wantPath    string // of the error, none if empty
+		wantUnknown string
+	}{
+		{"standard", DecodeStandard, `{"product":{"images":[{"width":220}]}}`, "", ""},
+		{"standard quoted number", DecodeStandard, `{"product":{"images":[{"width":220},{"width":"220"}]}}`, "product.images[1].width", ""},
+		{"lenient quoted number", DecodeLenient, `{"product":{"images":[{"width":"220"}]}}`, "", ""},
+		{"lenient number as string", DecodeLenient, `{"product":{"variants":[{"title":5}]}}`, "", ""},
+		{"lenient invalid number", DecodeLenient, `{"product":{"images":[{"width":"wide"}]}}`, "product.images[0].width", ""},
+		{"standard nested array", DecodeStandard, `{"product":{"variants":[{"title":"S"},{"title":5}]}}`, "product.variants[1].title", ""},
+		{"invalid id", DecodeStandard, `{"product":{"images":[{"id":"1"},{"id":{}}]}}`, "product.images[1].id", ""},
+		{"invalid flex int", DecodeLenient, `{"product":{"variants":[{"count":"many"}]}}`, "product.variants[0].count", ""},
+		{"standard unknown fields", DecodeStandard, `{"product":{"extra":1,"images":[{"color":"red"}]}}`, "", ""},
+		{"strict unknown fields", DecodeStrict, `{"product":{"extra":1,"images":[{"color":"red"}]}}`, "product.extra", "product.extra,product.images[0].color"},
+		{"strict known fields", DecodeStrict, `{"product":{"images":[{"id":"1","width":220}]}}`, "", ""},
+		{"syntax error", DecodeLenient, `{"product":`, "offset 11", ""},
+	}
+	for _, c := range cases {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(c.body))
+		}), WithDecodingMode(c.mode))
+
+		var product decodeProduct
+		err := client.Get("products/1", &product, nil)
+
+		if c.wantPath == "" {
+			if err != nil {
+				t.Errorf("%s: error = %v, want nil", c.name, err)
+			}
+			continue
+		}
+		var decodingErr ResponseDecodingError
+		if !errors.As(err, &decodingErr) {
+			t.Errorf("%s: error = %v, want a ResponseDecodingError", c.name, err)
+			continue
+		}
+		if decodingErr.JSONPath != c.wantPath {
+			t.Errorf("%s: JSONPath = %q, want %q", c.name, decodingErr.JSONPath, c.wantPath)
+		}
+		if got := strings.Join(decodingErr.UnknownFields, ","); got != c.wantUnknown {
+			t.Errorf("%s: UnknownFields = %q, want %q", c.name, got, c.wantUnknown)
+		}
+	}
+}
+
+func TestDecodeLenientValues(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"product":{"images":[{"id":1,"width":"220"},{"width":""}],"variants":[{"title":5,"count":"3"}]}}`))
+	}), WithDecodingMode(DecodeLenient))
+
+	var product decodeProduct
+	if err := client.Get("products/1", &product, nil); err != nil {
+		t.Fatal(err)
+	}
+	images, variants := product.Product.Images, product.Product.Variants
+	if len(images) != 2 || images[0].ID != "1" || images[0].Width != 220 || images[1].Width != 0 {
+		t.Errorf("images = %+v", images)
+	}
+	if len(variants) != 1 || variants[0].Title != "5" || variants[0].Count != 3 {
+		t.Errorf("variants = %+v", variants)
+	}
+}
@@ -0,0 +1,241 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const draftOrdersBasePath = "draft_orders"
+
+// DraftOrderService is an interface for interfacing with the draft orders
+// endpoints of the Shoplazza API.
+type DraftOrderService interface {
+	List(interface{}) ([]DraftOrder, error)
+	ListWithContext(context.Context, interface{}) ([]DraftOrder, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*DraftOrder, error)
+	GetWithContext(context.Context, ID, interface{}) (*DraftOrder, error)
+	Create(DraftOrder) (*DraftOrder, error)
+	CreateWithContext(context.Context, DraftOrder) (*DraftOrder, error)
+	Update(DraftOrder) (*DraftOrder, error)
+	UpdateWithContext(context.Context, DraftOrder) (*DraftOrder, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	SendInvoice(ID, DraftOrderInvoice) (*DraftOrderInvoice, error)
+	SendInvoiceWithContext(context.Context, ID, DraftOrderInvoice) (*DraftOrderInvoice, error)
+	Complete(ID, bool) (*Order, error)
+	CompleteWithContext(context.Context, ID, bool) (*Order, error)
+}
+
+// DraftOrderServiceOp handles communication with the draft order related
+// methods of the Shoplazza API.
+type DraftOrderServiceOp struct {
+	client *Client
+}
+
+// DraftOrder represents a Shoplazza draft order, e.g. a quote sent to a
+// customer. Once completed, OrderID identifies the order created from it.
+type DraftOrder struct {
+	ID              ID               `json:"id,omitempty"`
+	OrderID         ID               `json:"order_id,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	Customer        *Customer        `json:"customer,omitempty"`
+	ShippingAddress *Address         `json:"shipping_address,omitempty"`
+	BillingAddress  *Address         `json:"billing_address,omitempty"`
+	Note            string           `json:"note,omitempty"`
+	NoteAttributes  []NoteAttribute  `json:"note_attributes,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	Currency        string           `json:"currency,omitempty"`
+	InvoiceSentAt   *time.Time       `json:"invoice_sent_at,omitempty"`
+	InvoiceURL      string           `json:"invoice_url,omitempty"`
+	LineItems       []LineItem       `json:"line_items,omitempty"`
+	ShippingLine    *ShippingLine    `json:"shipping_line,omitempty"`
+	Tags            string           `json:"tags,omitempty"`
+	TaxExempt       bool             `json:"tax_exempt,omitempty"`
+	TaxLines        []TaxLine        `json:"tax_lines,omitempty"`
+	TaxesIncluded   bool             `json:"taxes_included,omitempty"`
+	SubtotalPrice   *decimal.Decimal `json:"subtotal_price,omitempty"`
+	TotalTax        *decimal.Decimal `json:"total_tax,omitempty"`
+	TotalPrice      *decimal.Decimal `json:"total_price,omitempty"`
+	Status          string           `json:"status,omitempty"`
+	CreatedAt       *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time       `json:"updated_at,omitempty"`
+	CompletedAt     *time.Time       `json:"completed_at,omitempty"`
+}
+
+// DraftOrderListOptions can be used for filtering draft orders on a List
+// request.
+type DraftOrderListOptions struct {
+	Page         int       `url:"page,omitempty"`
+	Limit        int       `url:"limit,omitempty"`
+	SinceID      ID        `url:"since_id,omitempty"`
+	Status       string    `url:"status,omitempty"`
+	UpdatedAtMin time.Time `url:"updated_at_min,omitempty"`
+	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
+	Fields       string    `url:"fields,omitempty"`
+	IDs          []ID      `url:"ids,omitempty,comma"`
+}
+
+// DraftOrderInvoice is the email sent to the customer of a draft order. All
+// fields are optional, Shoplazza falls back to the draft order's email and
+// the shop's invoice template.
+type DraftOrderInvoice struct {
+	To            string   `json:"to,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+}
+
+// DraftOrderResource represents the result from the draft_orders/X endpoint
+type DraftOrderResource struct {
+	DraftOrder *DraftOrder `json:"draft_order"`
+}
+
+// DraftOrdersResource represents the result from the draft_orders endpoint
+type DraftOrdersResource struct {
+	DraftOrders []DraftOrder `json:"draft_orders"`
+}
+
+// DraftOrderInvoiceResource represents the result from the
+// draft_orders/X/send_invoice endpoint
+type DraftOrderInvoiceResource struct {
+	DraftOrderInvoice *DraftOrderInvoice `json:"draft_order_invoice"`
+}
+
+type draftOrderCompleteOptions struct {
+	PaymentPending bool `url:"payment_pending,omitempty"`
+}
+
+// List draft orders
+func (s *DraftOrderServiceOp) List(options interface{}) ([]DraftOrder, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists draft orders using ctx for the request
+func (s *DraftOrderServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]DraftOrder, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, draftOrdersBasePath)
+	resource := new(DraftOrdersResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.DraftOrders, err
+}
+
+// Count draft orders
+func (s *DraftOrderServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts draft orders using ctx for the request
+func (s *DraftOrderServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, draftOrdersBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual draft order
+func (s *DraftOrderServiceOp) Get(draftOrderID ID, options interface{}) (*DraftOrder, error) {
+	return s.GetWithContext(context.Background(), draftOrderID, options)
+}
+
+// GetWithContext gets an individual draft order using ctx for the request
+func (s *DraftOrderServiceOp) GetWithContext(ctx context.Context, draftOrderID ID, options interface{}) (*DraftOrder, error) {
+	if err := requireIDs(draftOrderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, draftOrdersBasePath, draftOrderID.escape())
+	resource := new(DraftOrderResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.DraftOrder, err
+}
+
+// Create a new draft order
+func (s *DraftOrderServiceOp) Create(draftOrder DraftOrder) (*DraftOrder, error) {
+	return s.CreateWithContext(context.Background(), draftOrder)
+}
+
+// CreateWithContext creates a new draft order using ctx for the request
+func (s *DraftOrderServiceOp) CreateWithContext(ctx context.Context, draftOrder DraftOrder) (*DraftOrder, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, draftOrdersBasePath)
+	wrappedData := DraftOrderResource{DraftOrder: &draftOrder}
+	resource := new(DraftOrderResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.DraftOrder, err
+}
+
+// Update an existing draft order
+func (s *DraftOrderServiceOp) Update(draftOrder DraftOrder) (*DraftOrder, error) {
+	return s.UpdateWithContext(context.Background(), draftOrder)
+}
+
+// UpdateWithContext updates an existing draft order using ctx for the request
+func (s *DraftOrderServiceOp) UpdateWithContext(ctx context.Context, draftOrder DraftOrder) (*DraftOrder, error) {
+	if err := requireIDs(draftOrder.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, draftOrdersBasePath, draftOrder.ID.escape())
+	wrappedData := DraftOrderResource{DraftOrder: &draftOrder}
+	resource := new(DraftOrderResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.DraftOrder, err
+}
+
+// Delete an existing draft order
+func (s *DraftOrderServiceOp) Delete(draftOrderID ID) error {
+	return s.DeleteWithContext(context.Background(), draftOrderID)
+}
+
+// DeleteWithContext deletes an existing draft order using ctx for the request
+func (s *DraftOrderServiceOp) DeleteWithContext(ctx context.Context, draftOrderID ID) error {
+	if err := requireIDs(draftOrderID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, draftOrdersBasePath, draftOrderID.escape()))
+}
+
+// SendInvoice emails the invoice of a draft order to the customer
+func (s *DraftOrderServiceOp) SendInvoice(draftOrderID ID, invoice DraftOrderInvoice) (*DraftOrderInvoice, error) {
+	return s.SendInvoiceWithContext(context.Background(), draftOrderID, invoice)
+}
+
+// SendInvoiceWithContext emails the invoice of a draft order using ctx for
+// the request
+func (s *DraftOrderServiceOp) SendInvoiceWithContext(ctx context.Context, draftOrderID ID, invoice DraftOrderInvoice) (*DraftOrderInvoice, error) {
+	if err := requireIDs(draftOrderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/send_invoice", s.client.pathPrefix, draftOrdersBasePath, draftOrderID.escape())
+	wrappedData := DraftOrderInvoiceResource{DraftOrderInvoice: &invoice}
+	resource := new(DraftOrderInvoiceResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.DraftOrderInvoice, err
+}
+
+// Complete turns a draft order into an order and returns the created
+// order. With paymentPending the order is marked as awaiting payment,
+// otherwise as paid.
+func (s *DraftOrderServiceOp) Complete(draftOrderID ID, paymentPending bool) (*Order, error) {
+	return s.CompleteWithContext(context.Background(), draftOrderID, paymentPending)
+}
+
+// CompleteWithContext completes a draft order using ctx for the requests
+func (s *DraftOrderServiceOp) CompleteWithContext(ctx context.Context, draftOrderID ID, paymentPending bool) (*Order, error) {
+	if err := requireIDs(draftOrderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/complete", s.client.pathPrefix, draftOrdersBasePath, draftOrderID.escape())
+	options := draftOrderCompleteOptions{PaymentPending: paymentPending}
+	resource := new(DraftOrderResource)
+	err := s.client.CreateAndDoWithContext(ctx, "PUT", path, nil, options, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// The completed draft order only references the order created from it
+	if resource.DraftOrder == nil || resource.DraftOrder.OrderID == "" {
+		return nil, fmt.Errorf("goshoplazza: completed draft order %s has no order id", draftOrderID)
+	}
+	return s.client.Order.GetWithContext(ctx, resource.DraftOrder.OrderID, nil)
+}
@@ -0,0 +1,43 @@
+package goshoplazza
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDraftOrderComplete(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/openapi/draft_orders/d1/complete":
+			w.Write([]byte(`{"draft_order":{"id":"d1","status":"completed","order_id":"o1"}}`))
+		case "/openapi/orders/o1":
+			w.Write([]byte(`{"order":{"id":"o1","name":"#1001"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	order, err := client.DraftOrder.Complete("d1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.ID != "o1" || order.Name != "#1001" {
+		t.Errorf("Complete returned %+v, want order o1", order)
+	}
+	want := []string{"PUT /openapi/draft_orders/d1/complete?payment_pending=true", "GET /openapi/orders/o1"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+}
+
+func TestDraftOrderCompleteWithoutOrder(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"draft_order":{"id":"d1","status":"open"}}`))
+	}))
+
+	if _, err := client.DraftOrder.Complete("d1", false); err == nil {
+		t.Error("Complete without an order id returned no error")
+	}
+}
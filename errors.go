@@ -0,0 +1,98 @@
+package goshoplazza
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors matching the response errors of the corresponding status,
+// e.g. errors.Is(err, ErrNotFound).
+var (
+	ErrNotFound     = errors.New("goshoplazza: not found")
+	ErrUnauthorized = errors.New("goshoplazza: unauthorized")
+	ErrForbidden    = errors.New("goshoplazza: forbidden")
+)
+
+// ValidationError occurs when Shoplazza rejects the submitted resource,
+// usually with 422 Unprocessable Entity. Fields holds the messages per
+// field when Shoplazza reported them as an object, e.g.
+// {"errors": {"title": ["can't be blank"]}}.
+//
+// Such responses used to be returned as a plain ResponseError, a type
+// assertion err.(ResponseError) no longer matches them. Use
+// errors.As(err, &responseErr) instead, it unwraps the ResponseError.
+type ValidationError struct {
+	ResponseError
+	Fields map[string][]string
+}
+
+func (e ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.ResponseError.Error()
+	}
+
+	var messages []string
+	for field, fieldMessages := range e.Fields {
+		for _, message := range fieldMessages {
+			messages = append(messages, field+": "+message)
+		}
+	}
+	sort.Strings(messages)
+	return requestPrefix(e.Method, e.Path) + strings.Join(messages, ", ")
+}
+
+// Unwrap returns the underlying ResponseError
+func (e ValidationError) Unwrap() error {
+	return e.ResponseError
+}
+
+// ServerError occurs when Shoplazza fails with a 5xx status. Like
+// ValidationError, it is no longer matched by a type assertion
+// err.(ResponseError), use errors.As.
+type ServerError struct {
+	ResponseError
+}
+
+// Unwrap returns the underlying ResponseError
+func (e ServerError) Unwrap() error {
+	return e.ResponseError
+}
+
+// Unwrap returns the underlying ResponseError
+func (e RateLimitError) Unwrap() error {
+	return e.ResponseError
+}
+
+// Is reports whether the error matches target, one of the sentinel errors
+// of this package, by its status.
+func (e ResponseError) Is(target error) bool {
+	return statusIs(e.Status, target)
+}
+
+// Is reports whether the error matches target, one of the sentinel errors
+// of this package, by its status.
+func (e ResponseDecodingError) Is(target error) bool {
+	return statusIs(e.Status, target)
+}
+
+func statusIs(status int, target error) bool {
+	switch target {
+	case ErrNotFound:
+		return status == http.StatusNotFound
+	case ErrUnauthorized:
+		return status == http.StatusUnauthorized
+	case ErrForbidden:
+		return status == http.StatusForbidden
+	}
+	return false
+}
+
+// requestPrefix prefixes error messages with the failed request, if known
+func requestPrefix(method, path string) string {
+	if method == "" {
+		return ""
+	}
+	return method + " " + path + ": "
+}
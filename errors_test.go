@@ -0,0 +1,67 @@
+package goshoplazza
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestResponseErrors(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     int
+		body       string
+		wantMsg    string
+		wantType   string
+		wantStatus int
+	}{
+		{"not found", http.StatusNotFound, `{"error":"Not Found"}`, "GET /openapi/orders/1: Not Found", "ResponseError", 404},
+		{"validation", http.StatusUnprocessableEntity, `{"errors":{"title":["can't be blank"]}}`, "GET /openapi/orders/1: title: can't be blank", "ValidationError", 422},
+		{"server", http.StatusBadGateway, `{"errors":"Bad Gateway"}`, "GET /openapi/orders/1: Bad Gateway", "ServerError", 502},
+		{"html server error", http.StatusBadGateway, "<html>Bad Gateway</html>\n", "GET /openapi/orders/1: <html>Bad Gateway</html>", "ServerError", 502},
+		{"plain text rate limit", http.StatusTooManyRequests, "Too Many Requests", "GET /openapi/orders/1: Too Many Requests", "RateLimitError", 429},
+		{"html not found", http.StatusNotFound, "<html>Not Found</html>", "GET /openapi/orders/1: <html>Not Found</html>", "ResponseError", 404},
+	}
+	for _, c := range cases {
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		}))
+		err := client.Get("openapi/orders/1", nil, nil)
+		if err == nil {
+			t.Errorf("%s: no error", c.name)
+			continue
+		}
+		if err.Error() != c.wantMsg {
+			t.Errorf("%s: Error() = %q, want %q", c.name, err.Error(), c.wantMsg)
+		}
+
+		var gotType string
+		switch err.(type) {
+		case ResponseError:
+			gotType = "ResponseError"
+		case ValidationError:
+			gotType = "ValidationError"
+		case ServerError:
+			gotType = "ServerError"
+		case RateLimitError:
+			gotType = "RateLimitError"
+		}
+		if gotType != c.wantType {
+			t.Errorf("%s: error of type %T, want %s", c.name, err, c.wantType)
+		}
+
+		// errors.As matches the embedded ResponseError of the specific types
+		var responseErr ResponseError
+		if !errors.As(err, &responseErr) || responseErr.Status != c.wantStatus {
+			t.Errorf("%s: errors.As(ResponseError) = %+v, want status %d", c.name, responseErr, c.wantStatus)
+		}
+	}
+}
+
+func TestResponseErrorWithoutRequest(t *testing.T) {
+	err := ResponseError{Status: 400, Message: "Bad Request"}
+	if err.Error() != "Bad Request" {
+		t.Errorf("Error() = %q, want the message alone", err.Error())
+	}
+}
@@ -0,0 +1,170 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+// Shoplazza is not consistent in the JSON types of scalar fields, e.g. the
+// width of an image is sent as both 220 and "220". The flexible types below
+// decode from either form. Empty strings and null decode to the zero value.
+
+// FlexInt is an integer decoding from a JSON number or string. It encodes as
+// a JSON number.
+type FlexInt int
+
+// Int returns the value as an int
+func (i FlexInt) Int() int {
+	return int(i)
+}
+
+// MarshalJSON encodes the value as a JSON number
+func (i FlexInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(i))), nil
+}
+
+// UnmarshalJSON decodes the value from a JSON number or string
+func (i *FlexInt) UnmarshalJSON(data []byte) error {
+	n, err := flexInteger(data, strconv.IntSize)
+	*i = FlexInt(n)
+	return err
+}
+
+// FlexInt64 is a 64-bit integer decoding from a JSON number or string, for
+// values that may not fit in an int on 32-bit platforms. It encodes as a
+// JSON number.
+type FlexInt64 int64
+
+// Int64 returns the value as an int64
+func (i FlexInt64) Int64() int64 {
+	return int64(i)
+}
+
+// MarshalJSON encodes the value as a JSON number
+func (i FlexInt64) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(i), 10)), nil
+}
+
+// UnmarshalJSON decodes the value from a JSON number or string
+func (i *FlexInt64) UnmarshalJSON(data []byte) error {
+	n, err := flexInteger(data, 64)
+	*i = FlexInt64(n)
+	return err
+}
+
+// flexInteger parses the JSON scalar data as an integer of bitSize bits,
+// accepting integral floats such as 220.0
+func flexInteger(data []byte, bitSize int) (int64, error) {
+	s, err := flexScalar(data)
+	if err != nil || s == "" {
+		return 0, err
+	}
+
+	n, err := strconv.ParseInt(s, 10, bitSize)
+	if err == nil {
+		return n, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	limit := math.Ldexp(1, bitSize-1)
+	if err != nil || f != math.Trunc(f) || f < -limit || f >= limit {
+		return 0, fmt.Errorf("goshoplazza: invalid integer %s", data)
+	}
+	return int64(f), nil
+}
+
+// FlexDecimal is a decimal decoding from a JSON number or string. It encodes
+// like decimal.Decimal.
+type FlexDecimal decimal.Decimal
+
+// Decimal returns the value as a decimal.Decimal
+func (d FlexDecimal) Decimal() decimal.Decimal {
+	return decimal.Decimal(d)
+}
+
+// Float64 returns the nearest float64 of the value
+func (d FlexDecimal) Float64() float64 {
+	f, _ := decimal.Decimal(d).Float64()
+	return f
+}
+
+func (d FlexDecimal) String() string {
+	return decimal.Decimal(d).String()
+}
+
+// MarshalJSON encodes the value like decimal.Decimal
+func (d FlexDecimal) MarshalJSON() ([]byte, error) {
+	return decimal.Decimal(d).MarshalJSON()
+}
+
+// UnmarshalJSON decodes the value from a JSON number or string
+func (d *FlexDecimal) UnmarshalJSON(data []byte) error {
+	s, err := flexScalar(data)
+	if err != nil || s == "" {
+		*d = FlexDecimal(decimal.Zero)
+		return err
+	}
+
+	value, err := decimal.NewFromString(s)
+	if err != nil {
+		return fmt.Errorf("goshoplazza: invalid decimal %s", data)
+	}
+	*d = FlexDecimal(value)
+	return nil
+}
+
+// FlexBool is a boolean decoding from a JSON boolean, number or string such
+// as "true" or "1". It encodes as a JSON boolean.
+type FlexBool bool
+
+// Bool returns the value as a bool
+func (b FlexBool) Bool() bool {
+	return bool(b)
+}
+
+// MarshalJSON encodes the value as a JSON boolean
+func (b FlexBool) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatBool(bool(b))), nil
+}
+
+// UnmarshalJSON decodes the value from a JSON boolean, number or string
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	s, err := flexScalar(data)
+	if err != nil || s == "" {
+		*b = false
+		return err
+	}
+
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("goshoplazza: invalid boolean %s", data)
+	}
+	*b = FlexBool(value)
+	return nil
+}
+
+// flexScalar returns the JSON scalar data as a string, which is empty for
+// null and empty strings.
+func flexScalar(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return "", nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return string(bytes.TrimSpace([]byte(s))), nil
+	}
+
+	if len(data) > 0 && (data[0] == '{' || data[0] == '[') {
+		return "", fmt.Errorf("goshoplazza: invalid scalar %s", data)
+	}
+	return string(data), nil
+}
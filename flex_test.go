@@ -0,0 +1,125 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFlexIntegers(t *testing.T) {
+	cases := []struct {
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{`220`, 220, false},
+		{`"220"`, 220, false},
+		{`" 220 "`, 220, false},
+		{`-3`, -3, false},
+		{`""`, 0, false},
+		{`null`, 0, false},
+		{`220.0`, 220, false},
+		{`"220.0"`, 220, false},
+		{`220.5`, 0, true},
+		{`"wide"`, 0, true},
+		{`true`, 0, true},
+		{`{}`, 0, true},
+		{`[1]`, 0, true},
+		{`9007199254740993`, 9007199254740993, false},
+		{`"9223372036854775807"`, 9223372036854775807, false},
+		{`9223372036854775808`, 0, true},
+		{`1e30`, 0, true},
+	}
+	for _, c := range cases {
+		var i64 FlexInt64
+		err := json.Unmarshal([]byte(c.json), &i64)
+		if (err != nil) != c.wantErr || int64(i64) != c.want {
+			t.Errorf("FlexInt64 %s = %d, %v, want %d, error %v", c.json, i64, err, c.want, c.wantErr)
+		}
+
+		if int64(int(c.want)) != c.want {
+			continue // does not fit in an int on this platform
+		}
+		var i FlexInt
+		err = json.Unmarshal([]byte(c.json), &i)
+		if (err != nil) != c.wantErr || int64(i) != c.want {
+			t.Errorf("FlexInt %s = %d, %v, want %d, error %v", c.json, i, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestFlexBool(t *testing.T) {
+	cases := []struct {
+		json    string
+		want    bool
+		wantErr bool
+	}{
+		{`true`, true, false},
+		{`"true"`, true, false},
+		{`1`, true, false},
+		{`"0"`, false, false},
+		{`""`, false, false},
+		{`null`, false, false},
+		{`"yes"`, false, true},
+	}
+	for _, c := range cases {
+		var b FlexBool
+		err := json.Unmarshal([]byte(c.json), &b)
+		if (err != nil) != c.wantErr || bool(b) != c.want {
+			t.Errorf("FlexBool %s = %v, %v, want %v, error %v", c.json, b, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestFlexDecimal(t *testing.T) {
+	cases := []struct {
+		json    string
+		want    string
+		wantErr bool
+	}{
+		{`19.99`, "19.99", false},
+		{`"19.99"`, "19.99", false},
+		{`""`, "0", false},
+		{`null`, "0", false},
+		{`"cheap"`, "0", true},
+	}
+	for _, c := range cases {
+		var d FlexDecimal
+		err := json.Unmarshal([]byte(c.json), &d)
+		if (err != nil) != c.wantErr || (err == nil && d.String() != c.want) {
+			t.Errorf("FlexDecimal %s = %s, %v, want %s, error %v", c.json, d, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestFlexRoundTrip(t *testing.T) {
+	type values struct {
+		Int     FlexInt     `json:"int"`
+		Int64   FlexInt64   `json:"int64"`
+		Bool    FlexBool    `json:"bool"`
+		Decimal FlexDecimal `json:"decimal"`
+	}
+	in := values{
+		Int:     220,
+		Int64:   9007199254740993,
+		Bool:    true,
+		Decimal: FlexDecimal(decimal.RequireFromString("19.99")),
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"int":220,"int64":9007199254740993,"bool":true,"decimal":"19.99"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var out values
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Int != in.Int || out.Int64 != in.Int64 || out.Bool != in.Bool || !out.Decimal.Decimal().Equal(in.Decimal.Decimal()) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
@@ -1,6 +1,7 @@
 package goshoplazza
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -10,27 +11,43 @@ import (
 // https://help.shopify.com/api/reference/fulfillment
 type FulfillmentService interface {
 	List(interface{}) ([]Fulfillment, error)
+	ListWithContext(context.Context, interface{}) ([]Fulfillment, error)
 	Count(interface{}) (int, error)
-	Get(string, interface{}) (*Fulfillment, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Fulfillment, error)
+	GetWithContext(context.Context, ID, interface{}) (*Fulfillment, error)
 	Create(Fulfillment) (*Fulfillment, error)
+	CreateWithContext(context.Context, Fulfillment) (*Fulfillment, error)
 	Update(Fulfillment) (*Fulfillment, error)
-	Complete(string) (*Fulfillment, error)
-	Transition(string) (*Fulfillment, error)
-	Cancel(string) (*Fulfillment, error)
+	UpdateWithContext(context.Context, Fulfillment) (*Fulfillment, error)
+	Complete(ID) (*Fulfillment, error)
+	CompleteWithContext(context.Context, ID) (*Fulfillment, error)
+	Transition(ID) (*Fulfillment, error)
+	TransitionWithContext(context.Context, ID) (*Fulfillment, error)
+	Cancel(ID) (*Fulfillment, error)
+	CancelWithContext(context.Context, ID) (*Fulfillment, error)
 }
 
 // FulfillmentsService is an interface for other Shopify resources
 // to interface with the fulfillment endpoints of the Shopify API.
 // https://help.shopify.com/api/reference/fulfillment
 type FulfillmentsService interface {
-	ListFulfillments(string, interface{}) ([]Fulfillment, error)
-	CountFulfillments(string, interface{}) (int, error)
-	GetFulfillment(string, string, interface{}) (*Fulfillment, error)
-	CreateFulfillment(string, Fulfillment) (*Fulfillment, error)
-	UpdateFulfillment(string, Fulfillment) (*Fulfillment, error)
-	CompleteFulfillment(string, string) (*Fulfillment, error)
-	TransitionFulfillment(string, string) (*Fulfillment, error)
-	CancelFulfillment(string, string) (*Fulfillment, error)
+	ListFulfillments(ID, interface{}) ([]Fulfillment, error)
+	ListFulfillmentsWithContext(context.Context, ID, interface{}) ([]Fulfillment, error)
+	CountFulfillments(ID, interface{}) (int, error)
+	CountFulfillmentsWithContext(context.Context, ID, interface{}) (int, error)
+	GetFulfillment(ID, ID, interface{}) (*Fulfillment, error)
+	GetFulfillmentWithContext(context.Context, ID, ID, interface{}) (*Fulfillment, error)
+	CreateFulfillment(ID, Fulfillment) (*Fulfillment, error)
+	CreateFulfillmentWithContext(context.Context, ID, Fulfillment) (*Fulfillment, error)
+	UpdateFulfillment(ID, Fulfillment) (*Fulfillment, error)
+	UpdateFulfillmentWithContext(context.Context, ID, Fulfillment) (*Fulfillment, error)
+	CompleteFulfillment(ID, ID) (*Fulfillment, error)
+	CompleteFulfillmentWithContext(context.Context, ID, ID) (*Fulfillment, error)
+	TransitionFulfillment(ID, ID) (*Fulfillment, error)
+	TransitionFulfillmentWithContext(context.Context, ID, ID) (*Fulfillment, error)
+	CancelFulfillment(ID, ID) (*Fulfillment, error)
+	CancelFulfillmentWithContext(context.Context, ID, ID) (*Fulfillment, error)
 }
 
 // FulfillmentServiceOp handles communication with the fulfillment
@@ -38,13 +55,22 @@ type FulfillmentsService interface {
 type FulfillmentServiceOp struct {
 	client     *Client
 	resource   string
-	resourceID string
+	resourceID ID
+}
+
+// requireIDs returns ErrEmptyID if any of ids is empty, or if the service
+// handles the fulfillments of a resource without its ID
+func (s *FulfillmentServiceOp) requireIDs(ids ...ID) error {
+	if s.resource != "" {
+		ids = append(ids, s.resourceID)
+	}
+	return requireIDs(ids...)
 }
 
 // Fulfillment represents a Shopify fulfillment.
 type Fulfillment struct {
-	ID        string     `json:"id,omitempty"`
-	OrderID   string     `json:"order_id,omitempty"`
+	ID        ID         `json:"id,omitempty"`
+	OrderID   ID         `json:"order_id,omitempty"`
 	Status    string     `json:"status,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	// Service             string     `json:"service,omitempty"`
@@ -59,7 +85,7 @@ type Fulfillment struct {
 	// Receipt         Receipt    `json:"receipt,omitempty"`
 	LineItems []LineItem `json:"line_items,omitempty"`
 	// NotifyCustomer  bool       `json:"notify_customer"`
-	LineItemIDs []string `json:"line_item_ids,omitempty"`
+	LineItemIDs []ID `json:"line_item_ids,omitempty"`
 }
 
 // Receipt represents a Shopify receipt.
@@ -80,72 +106,136 @@ type FulfillmentsResource struct {
 
 // List fulfillments
 func (s *FulfillmentServiceOp) List(options interface{}) ([]Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists fulfillments using ctx for the request
+func (s *FulfillmentServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Fulfillment, error) {
+	if err := s.requireIDs(); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s", prefix)
 	resource := new(FulfillmentsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Fulfillments, err
 }
 
 // Count fulfillments
 func (s *FulfillmentServiceOp) Count(options interface{}) (int, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts fulfillments using ctx for the request
+func (s *FulfillmentServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	if err := s.requireIDs(); err != nil {
+		return 0, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s/count", prefix)
-	return s.client.Count(path, options)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual fulfillment
-func (s *FulfillmentServiceOp) Get(fulfillmentID string, options interface{}) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
-	path := fmt.Sprintf("%s/%s", prefix, fulfillmentID)
+func (s *FulfillmentServiceOp) Get(fulfillmentID ID, options interface{}) (*Fulfillment, error) {
+	return s.GetWithContext(context.Background(), fulfillmentID, options)
+}
+
+// GetWithContext gets an individual fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) GetWithContext(ctx context.Context, fulfillmentID ID, options interface{}) (*Fulfillment, error) {
+	if err := s.requireIDs(fulfillmentID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s", prefix, fulfillmentID.escape())
 	resource := new(FulfillmentResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Fulfillment, err
 }
 
 // Create a new fulfillment
 func (s *FulfillmentServiceOp) Create(fulfillment Fulfillment) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
+	return s.CreateWithContext(context.Background(), fulfillment)
+}
+
+// CreateWithContext creates a new fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) CreateWithContext(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	if err := s.requireIDs(); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
 	path := fmt.Sprintf("%s", prefix)
 	// wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, fulfillment, resource)
+	err := s.client.PostWithContext(ctx, path, fulfillment, resource)
 	return resource.Fulfillment, err
 }
 
 // Update an existing fulfillment
 func (s *FulfillmentServiceOp) Update(fulfillment Fulfillment) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
-	path := fmt.Sprintf("%s/%d", prefix, fulfillment.ID)
+	return s.UpdateWithContext(context.Background(), fulfillment)
+}
+
+// UpdateWithContext updates an existing fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) UpdateWithContext(ctx context.Context, fulfillment Fulfillment) (*Fulfillment, error) {
+	if err := s.requireIDs(fulfillment.ID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s", prefix, fulfillment.ID.escape())
 	wrappedData := FulfillmentResource{Fulfillment: &fulfillment}
 	resource := new(FulfillmentResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Fulfillment, err
 }
 
 // Complete an existing fulfillment
-func (s *FulfillmentServiceOp) Complete(fulfillmentID string) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
-	path := fmt.Sprintf("%s/%d/complete", prefix, fulfillmentID)
+func (s *FulfillmentServiceOp) Complete(fulfillmentID ID) (*Fulfillment, error) {
+	return s.CompleteWithContext(context.Background(), fulfillmentID)
+}
+
+// CompleteWithContext completes an existing fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) CompleteWithContext(ctx context.Context, fulfillmentID ID) (*Fulfillment, error) {
+	if err := s.requireIDs(fulfillmentID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s/complete", prefix, fulfillmentID.escape())
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
 
 // Transition an existing fulfillment
-func (s *FulfillmentServiceOp) Transition(fulfillmentID string) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
-	path := fmt.Sprintf("%s/%d/open", prefix, fulfillmentID)
+func (s *FulfillmentServiceOp) Transition(fulfillmentID ID) (*Fulfillment, error) {
+	return s.TransitionWithContext(context.Background(), fulfillmentID)
+}
+
+// TransitionWithContext transitions an existing fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) TransitionWithContext(ctx context.Context, fulfillmentID ID) (*Fulfillment, error) {
+	if err := s.requireIDs(fulfillmentID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s/open", prefix, fulfillmentID.escape())
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
 
 // Cancel an existing fulfillment
-func (s *FulfillmentServiceOp) Cancel(fulfillmentID string) (*Fulfillment, error) {
-	prefix := FulfillmentPathPrefix(s.resource, s.resourceID)
-	path := fmt.Sprintf("%s/%d/cancel", prefix, fulfillmentID)
+func (s *FulfillmentServiceOp) Cancel(fulfillmentID ID) (*Fulfillment, error) {
+	return s.CancelWithContext(context.Background(), fulfillmentID)
+}
+
+// CancelWithContext cancels an existing fulfillment using ctx for the request
+func (s *FulfillmentServiceOp) CancelWithContext(ctx context.Context, fulfillmentID ID) (*Fulfillment, error) {
+	if err := s.requireIDs(fulfillmentID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.FulfillmentPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s/cancel", prefix, fulfillmentID.escape())
 	resource := new(FulfillmentResource)
-	err := s.client.Post(path, nil, resource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Fulfillment, err
 }
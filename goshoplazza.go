@@ -2,9 +2,11 @@ package goshoplazza
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -19,13 +21,14 @@ import (
 
 const (
 	UserAgent = "goshoplazza/0.0.1"
-)
 
-var (
-	// Shopify API version YYYY-MM - defaults to admin which uses the oldest stable version of the api
-	globalApiPathPrefix string = "openapi"
+	// Path prefix of the unversioned Shoplazza open API
+	defaultApiPathPrefix = "openapi"
 )
 
+// Shoplazza API versions have the form YYYY-MM, e.g. 2020-01
+var apiVersionRegex = regexp.MustCompile(`^[0-9]{4}-(0[1-9]|1[0-2])$`)
+
 // App represents basic app settings such as Api key, secret, scope, and redirect url.
 // See oauth.go for OAuth related helper functions.
 type App struct {
@@ -52,31 +55,54 @@ type Client struct {
 	// A permanent access token
 	token string
 
+	// API path prefix, including the api version if one was set
+	pathPrefix string
+
+	// Retry policy applied by Do, nil disables retries
+	retry *RetryPolicy
+
+	// Limiter pacing the requests, may be shared between clients
+	limiter *RateLimiter
+
+	// Middlewares wrapping the sending of requests, outermost first
+	middleware []Middleware
+
+	// Optional request logging, see WithLogger and WithBodyLogging
+	logger       *slog.Logger
+	logBodyBytes int
+
+	// How response bodies are decoded, see WithDecodingMode
+	decodingMode DecodingMode
+
+	// Tracing and metrics, no-op unless a provider is set
+	telemetry telemetry
+
 	// Services used for communicating with the API
-	Product ProductService
-	// CustomCollection           CustomCollectionService
-	// SmartCollection            SmartCollectionService
-	// Customer                   CustomerService
-	// CustomerAddress            CustomerAddressService
-	Order OrderService
-	// DraftOrder                 DraftOrderService
+	Product          ProductService
+	CustomCollection CustomCollectionService
+	SmartCollection  SmartCollectionService
+	Customer         CustomerService
+	CustomerAddress  CustomerAddressService
+	Order            OrderService
+	DraftOrder       DraftOrderService
+	Refund           RefundService
 	// Shop                       ShopService
-	// Webhook                    WebhookService
-	Variant VariantService
-	Image   ImageService
-	// Transaction                TransactionService
+	Webhook     WebhookService
+	Variant     VariantService
+	Image       ImageService
+	Transaction TransactionService
 	// Theme                      ThemeService
 	// Asset                      AssetService
 	// ScriptTag                  ScriptTagService
 	// RecurringApplicationCharge RecurringApplicationChargeService
 	// UsageCharge                UsageChargeService
-	// Metafield                  MetafieldService
+	Metafield MetafieldService
 	// Blog                       BlogService
 	// ApplicationCharge          ApplicationChargeService
 	// Redirect                   RedirectService
 	// Page                       PageService
 	// StorefrontAccessToken      StorefrontAccessTokenService
-	// Collect                    CollectService
+	Collect CollectService
 	// Location                   LocationService
 	// DiscountCode               DiscountCodeService
 	// InventoryItem              InventoryItemService
@@ -84,13 +110,22 @@ type Client struct {
 
 // A general response error that follows a similar layout to Shopify's response
 // errors, i.e. either a single message or a list of messages.
+// Method and Path identify the failed request and Body holds the raw
+// response body.
 type ResponseError struct {
 	Status  int
 	Message string
 	Errors  []string
+	Method  string
+	Path    string
+	Body    []byte
 }
 
 func (e ResponseError) Error() string {
+	return requestPrefix(e.Method, e.Path) + e.message()
+}
+
+func (e ResponseError) message() string {
 	if e.Message != "" {
 		return e.Message
 	}
@@ -105,16 +140,24 @@ func (e ResponseError) Error() string {
 	return "Unknown Error"
 }
 
-// ResponseDecodingError occurs when the response body from Shopify could
-// not be parsed.
+// ResponseDecodingError occurs when the body of a successful response from
+// Shopify could not be parsed. Error responses that are not JSON are
+// returned as the ResponseError of their status instead. JSONPath locates
+// the offending value, e.g. "product.images[0].width", also when a type
+// decoding itself such as ID or FlexInt failed. UnknownFields lists the
+// fields rejected by DecodeStrict, in the same format.
 type ResponseDecodingError struct {
-	Body    []byte
-	Message string
-	Status  int
+	Body          []byte
+	Message       string
+	Status        int
+	Method        string
+	Path          string
+	JSONPath      string
+	UnknownFields []string
 }
 
 func (e ResponseDecodingError) Error() string {
-	return e.Message
+	return requestPrefix(e.Method, e.Path) + e.Message
 }
 
 // An error specific to a rate-limiting response. Embeds the ResponseError to
@@ -129,6 +172,12 @@ type RateLimitError struct {
 // specified without a preceding slash. If specified, the value pointed to by
 // body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body, options)
+}
+
+// NewRequestWithContext is like NewRequest but attaches ctx to the returned
+// request, so cancellation and deadlines on ctx apply to the API call.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body, options interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -162,7 +211,7 @@ func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), bytes.NewBuffer(js))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewBuffer(js))
 	if err != nil {
 		return nil, err
 	}
@@ -182,18 +231,33 @@ func (c *Client) NewRequest(method, urlStr string, body, options interface{}) (*
 // Option is used to configure client with options
 type Option func(c *Client)
 
-// WithVersion optionally sets the api-version if the passed string is valid
+// WithVersion optionally sets the api-version if the passed string is valid,
+// i.e. of the form YYYY-MM. Otherwise the unversioned api is used.
 func WithVersion(apiVersion string) Option {
 	return func(c *Client) {
-		var rxPat = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}$`)
-		if len(apiVersion) > 0 && rxPat.MatchString(apiVersion) {
-			globalApiPathPrefix = fmt.Sprintf("admin/api/%s", apiVersion)
+		if ValidVersion(apiVersion) {
+			c.pathPrefix = fmt.Sprintf("%s/%s", defaultApiPathPrefix, apiVersion)
 		} else {
-			globalApiPathPrefix = "admin"
+			c.pathPrefix = defaultApiPathPrefix
 		}
 	}
 }
 
+// WithHTTPClient optionally sets the http.Client used to send requests,
+// e.g. one with a custom transport or timeout. The default is
+// http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.Client = httpClient
+	}
+}
+
+// ValidVersion reports whether apiVersion is a Shoplazza api version of the
+// form YYYY-MM.
+func ValidVersion(apiVersion string) bool {
+	return apiVersionRegex.MatchString(apiVersion)
+}
+
 // NewClient returns a new Shopify API client with an already authenticated shopname and
 // token. The shopName parameter is the shop's myshoplazza domain,
 // e.g. "theshop.myshoplazza.com", or simply "theshop"
@@ -210,31 +274,39 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 
 	baseURL, _ := url.Parse(ShopBaseUrl(shopName))
 
-	c := &Client{Client: httpClient, app: app, baseURL: baseURL, token: token}
+	c := &Client{
+		Client:     httpClient,
+		app:        app,
+		baseURL:    baseURL,
+		token:      token,
+		pathPrefix: defaultApiPathPrefix,
+		telemetry:  newTelemetry(),
+	}
 	c.Product = &ProductServiceOp{client: c}
-	// c.CustomCollection = &CustomCollectionServiceOp{client: c}
-	// c.SmartCollection = &SmartCollectionServiceOp{client: c}
-	// c.Customer = &CustomerServiceOp{client: c}
-	// c.CustomerAddress = &CustomerAddressServiceOp{client: c}
+	c.CustomCollection = &CustomCollectionServiceOp{client: c}
+	c.SmartCollection = &SmartCollectionServiceOp{client: c}
+	c.Customer = &CustomerServiceOp{client: c}
+	c.CustomerAddress = &CustomerAddressServiceOp{client: c}
 	c.Order = &OrderServiceOp{client: c}
-	// c.DraftOrder = &DraftOrderServiceOp{client: c}
+	c.DraftOrder = &DraftOrderServiceOp{client: c}
+	c.Refund = &RefundServiceOp{client: c}
 	// c.Shop = &ShopServiceOp{client: c}
-	// c.Webhook = &WebhookServiceOp{client: c}
+	c.Webhook = &WebhookServiceOp{client: c}
 	c.Variant = &VariantServiceOp{client: c}
 	c.Image = &ImageServiceOp{client: c}
-	// c.Transaction = &TransactionServiceOp{client: c}
+	c.Transaction = &TransactionServiceOp{client: c}
 	// c.Theme = &ThemeServiceOp{client: c}
 	// c.Asset = &AssetServiceOp{client: c}
 	// c.ScriptTag = &ScriptTagServiceOp{client: c}
 	// c.RecurringApplicationCharge = &RecurringApplicationChargeServiceOp{client: c}
-	// c.Metafield = &MetafieldServiceOp{client: c}
+	c.Metafield = &MetafieldServiceOp{client: c}
 	// c.Blog = &BlogServiceOp{client: c}
 	// c.ApplicationCharge = &ApplicationChargeServiceOp{client: c}
 	// c.Redirect = &RedirectServiceOp{client: c}
 	// c.Page = &PageServiceOp{client: c}
 	// c.StorefrontAccessToken = &StorefrontAccessTokenServiceOp{client: c}
 	// c.UsageCharge = &UsageChargeServiceOp{client: c}
-	// c.Collect = &CollectServiceOp{client: c}
+	c.Collect = &CollectServiceOp{client: c}
 	// c.Location = &LocationServiceOp{client: c}
 	// c.DiscountCode = &DiscountCodeServiceOp{client: c}
 	// c.InventoryItem = &InventoryItemServiceOp{client: c}
@@ -250,12 +322,46 @@ func NewClient(app App, shopName, token string, opts ...Option) *Client {
 // Do sends an API request and populates the given interface with the parsed
 // response. It does not make much sense to call Do without a prepared
 // interface instance.
+// The request is sent with the context it carries, see NewRequestWithContext.
+// If the client has a retry policy (see WithRetries), failed attempts are
+// retried according to that policy. Each attempt is sent through the
+// client's middlewares, see Client.Use.
 func (c *Client) Do(req *http.Request, v interface{}) error {
-	resp, err := c.Client.Do(req)
+	if c.retry == nil {
+		return c.do(req, v, 1)
+	}
+	return c.retry.do(c, req, v)
+}
+
+// do performs a single attempt of the given request
+func (c *Client) do(req *http.Request, v interface{}, attempt int) (err error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return err
+		}
+	}
+
+	var reqLog *requestLog
+	if c.logger != nil {
+		reqLog = c.startRequestLog(req, attempt)
+		defer func() { reqLog.finish(err) }()
+	}
+
+	resp, err := c.send(req)
 	if err != nil {
+		c.recordAttempt(req, 0)
 		return err
 	}
 	defer resp.Body.Close()
+	c.recordAttempt(req, resp.StatusCode)
+
+	if reqLog != nil {
+		reqLog.response(resp)
+	}
+
+	if c.limiter != nil {
+		c.limiter.Update(resp)
+	}
 
 	err = CheckResponseError(resp)
 	if err != nil {
@@ -263,25 +369,15 @@ func (c *Client) Do(req *http.Request, v interface{}) error {
 	}
 
 	if v != nil {
-		// content, e := ioutil.ReadAll(resp.Body)
-		// if e != nil {
-		// 	fmt.Println(err)
-		// }
-		// fmt.Println(string(content))
-
-		decoder := json.NewDecoder(resp.Body)
-		err := decoder.Decode(&v)
-		if err != nil {
-			return err
-		}
+		return c.decode(resp, v)
 	}
 
 	return nil
 }
 
-func wrapSpecificError(r *http.Response, err ResponseError) error {
+func wrapSpecificError(r *http.Response, err ResponseError, fields map[string][]string) error {
 	if err.Status == 429 {
-		f, _ := strconv.ParseFloat(r.Header.Get("retry-after"), 64)
+		f, _ := strconv.ParseFloat(r.Header.Get(retryAfterHeader), 64)
 		return RateLimitError{
 			ResponseError: err,
 			RetryAfter:    int(f),
@@ -290,6 +386,15 @@ func wrapSpecificError(r *http.Response, err ResponseError) error {
 	if err.Status == 406 {
 		err.Message = "Not acceptable"
 	}
+	if err.Status == 422 || err.Status == 400 && len(fields) > 0 {
+		return ValidationError{
+			ResponseError: err,
+			Fields:        fields,
+		}
+	}
+	if err.Status >= 500 {
+		return ServerError{ResponseError: err}
+	}
 	return err
 }
 
@@ -309,16 +414,26 @@ func CheckResponseError(r *http.Response) error {
 		return err
 	}
 
+	var method, path string
+	if r.Request != nil {
+		method = r.Request.Method
+		path = r.Request.URL.Path
+	}
+
 	// empty body, this probably means shoplazza returned an error with no body
-	// we'll handle that error in wrapSpecificError()
+	// we'll handle that error in wrapSpecificError(). A body that is not JSON,
+	// e.g. the html page of a gateway, is used as the message, the error is
+	// still typed by its status.
 	if len(bodyBytes) > 0 {
 		err := json.Unmarshal(bodyBytes, &shoplazzaError)
 		if err != nil {
-			return ResponseDecodingError{
-				Body:    bodyBytes,
-				Message: err.Error(),
+			return wrapSpecificError(r, ResponseError{
 				Status:  r.StatusCode,
-			}
+				Message: strings.TrimSpace(string(bodyBytes)),
+				Method:  method,
+				Path:    path,
+				Body:    bodyBytes,
+			}, nil)
 		}
 	}
 
@@ -326,11 +441,14 @@ func CheckResponseError(r *http.Response) error {
 	responseError := ResponseError{
 		Status:  r.StatusCode,
 		Message: shoplazzaError.Error,
+		Method:  method,
+		Path:    path,
+		Body:    bodyBytes,
 	}
 
 	// If the errors field is not filled out, we can return here.
 	if shoplazzaError.Errors == nil {
-		return wrapSpecificError(r, responseError)
+		return wrapSpecificError(r, responseError, nil)
 	}
 
 	// Shopify errors usually have the form:
@@ -360,31 +478,40 @@ func CheckResponseError(r *http.Response) error {
 	case reflect.Map:
 		// A map, parse each error for each key in the map.
 		// json always serializes into map[string]interface{} for objects
+		fields := map[string][]string{}
 		for k, v := range shoplazzaError.Errors.(map[string]interface{}) {
-			// Check to make sure the interface is a slice
+			// The messages of a key are usually a slice, but may be a single
+			// string.
 			// json always serializes JSON arrays into []interface{}
-			if reflect.TypeOf(v).Kind() == reflect.Slice {
-				for _, elem := range v.([]interface{}) {
-					// If the primary message of the response error is not set, use
-					// any message.
-					if responseError.Message == "" {
-						responseError.Message = fmt.Sprintf("%v: %v", k, elem)
-					}
-					topicAndElem := fmt.Sprintf("%v: %v", k, elem)
-					responseError.Errors = append(responseError.Errors, topicAndElem)
+			var elems []interface{}
+			switch v := v.(type) {
+			case []interface{}:
+				elems = v
+			case string:
+				elems = []interface{}{v}
+			}
+			for _, elem := range elems {
+				// If the primary message of the response error is not set, use
+				// any message.
+				if responseError.Message == "" {
+					responseError.Message = fmt.Sprintf("%v: %v", k, elem)
 				}
+				topicAndElem := fmt.Sprintf("%v: %v", k, elem)
+				responseError.Errors = append(responseError.Errors, topicAndElem)
+				fields[k] = append(fields[k], fmt.Sprint(elem))
 			}
 		}
+		return wrapSpecificError(r, responseError, fields)
 	}
 
-	return wrapSpecificError(r, responseError)
+	return wrapSpecificError(r, responseError, nil)
 }
 
 // General list options that can be used for most collections of entities.
 type ListOptions struct {
 	Page         int       `url:"page,omitempty"`
 	Limit        int       `url:"limit,omitempty"`
-	SinceID      int64     `url:"since_id,omitempty"`
+	SinceID      ID        `url:"since_id,omitempty"`
 	CreatedAtMin time.Time `url:"created_at_min,omitempty"`
 	CreatedAtMax time.Time `url:"created_at_max,omitempty"`
 	UpdatedAtMin time.Time `url:"updated_at_min,omitempty"`
@@ -392,7 +519,7 @@ type ListOptions struct {
 	Order        string    `url:"order,omitempty"`
 	Fields       string    `url:"fields,omitempty"`
 	Vendor       string    `url:"vendor,omitempty"`
-	IDs          []int64   `url:"ids,omitempty,comma"`
+	IDs          []ID      `url:"ids,omitempty,comma"`
 }
 
 // General count options that can be used for most collection counts.
@@ -404,10 +531,15 @@ type CountOptions struct {
 }
 
 func (c *Client) Count(path string, options interface{}) (int, error) {
+	return c.CountWithContext(context.Background(), path, options)
+}
+
+// CountWithContext is like Count but uses ctx for the request.
+func (c *Client) CountWithContext(ctx context.Context, path string, options interface{}) (int, error) {
 	resource := struct {
 		Count int `json:"count"`
 	}{}
-	err := c.Get(path, &resource, options)
+	err := c.GetWithContext(ctx, path, &resource, options)
 	return resource.Count, err
 }
 
@@ -421,7 +553,16 @@ func (c *Client) Count(path string, options interface{}) (int, error) {
 // parameters like created_at_min
 // Any data returned from Shopify will be marshalled into resource argument.
 func (c *Client) CreateAndDo(method, path string, data, options, resource interface{}) error {
-	req, err := c.NewRequest(method, path, data, options)
+	return c.CreateAndDoWithContext(context.Background(), method, path, data, options, resource)
+}
+
+// CreateAndDoWithContext is like CreateAndDo but uses ctx for the request.
+// Cancelling ctx aborts the request and any body read in progress.
+func (c *Client) CreateAndDoWithContext(ctx context.Context, method, path string, data, options, resource interface{}) (err error) {
+	ctx, end := c.startCall(ctx, method, path)
+	defer func() { end(err) }()
+
+	req, err := c.NewRequestWithContext(ctx, method, path, data, options)
 	if err != nil {
 		return err
 	}
@@ -437,22 +578,42 @@ func (c *Client) CreateAndDo(method, path string, data, options, resource interf
 // Get performs a GET request for the given path and saves the result in the
 // given resource.
 func (c *Client) Get(path string, resource, options interface{}) error {
-	return c.CreateAndDo("GET", path, nil, options, resource)
+	return c.GetWithContext(context.Background(), path, resource, options)
+}
+
+// GetWithContext is like Get but uses ctx for the request.
+func (c *Client) GetWithContext(ctx context.Context, path string, resource, options interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "GET", path, nil, options, resource)
 }
 
 // Post performs a POST request for the given path and saves the result in the
 // given resource.
 func (c *Client) Post(path string, data, resource interface{}) error {
-	return c.CreateAndDo("POST", path, data, nil, resource)
+	return c.PostWithContext(context.Background(), path, data, resource)
+}
+
+// PostWithContext is like Post but uses ctx for the request.
+func (c *Client) PostWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "POST", path, data, nil, resource)
 }
 
 // Put performs a PUT request for the given path and saves the result in the
 // given resource.
 func (c *Client) Put(path string, data, resource interface{}) error {
-	return c.CreateAndDo("PUT", path, data, nil, resource)
+	return c.PutWithContext(context.Background(), path, data, resource)
+}
+
+// PutWithContext is like Put but uses ctx for the request.
+func (c *Client) PutWithContext(ctx context.Context, path string, data, resource interface{}) error {
+	return c.CreateAndDoWithContext(ctx, "PUT", path, data, nil, resource)
 }
 
 // Delete performs a DELETE request for the given path
 func (c *Client) Delete(path string) error {
-	return c.CreateAndDo("DELETE", path, nil, nil, nil)
+	return c.DeleteWithContext(context.Background(), path)
+}
+
+// DeleteWithContext is like Delete but uses ctx for the request.
+func (c *Client) DeleteWithContext(ctx context.Context, path string) error {
+	return c.CreateAndDoWithContext(ctx, "DELETE", path, nil, nil, nil)
 }
@@ -0,0 +1,116 @@
+package goshoplazzatest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Fault makes the fake server fail matching requests instead of serving
+// them.
+type Fault struct {
+	// Method and Path restrict the requests failing, empty values match any
+	// request. Path is matched without the api prefix and .json suffix,
+	// e.g. "orders" or "orders/count", and may end in "*" to match a path
+	// prefix.
+	Method string
+	Path   string
+
+	// Response of the failing requests
+	Status int
+	Header http.Header
+	Body   string
+
+	// Number of requests failing before the fault is cleared, any value
+	// below 1 fails a single request
+	Times int
+}
+
+// RateLimited returns a fault responding with 429 and the given Retry-After
+// header, in seconds.
+func RateLimited(retryAfter int) Fault {
+	header := http.Header{}
+	header.Set("Retry-After", strconv.Itoa(retryAfter))
+	return Fault{
+		Status: http.StatusTooManyRequests,
+		Header: header,
+		Body:   `{"errors":"Exceeded request rate limit"}`,
+	}
+}
+
+// ServerError returns a fault responding with the given 5xx status
+func ServerError(status int) Fault {
+	return Fault{
+		Status: status,
+		Body:   `{"errors":"Internal Server Error"}`,
+	}
+}
+
+// MalformedBody returns a fault responding with the given status and a body
+// that is not valid JSON.
+func MalformedBody(status int) Fault {
+	return Fault{
+		Status: status,
+		Body:   `{"errors": [`,
+	}
+}
+
+// InjectFault makes the server fail the next requests matching fault. Faults
+// are matched in the order they were injected.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.Times < 1 {
+		fault.Times = 1
+	}
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching the request, consuming one of
+// its failures.
+func (s *Server) takeFault(method, path string) *Fault {
+	for i, fault := range s.faults {
+		if !fault.matches(method, path) {
+			continue
+		}
+		fault.Times--
+		if fault.Times <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+func (f *Fault) matches(method, path string) bool {
+	if f.Method != "" && f.Method != method {
+		return false
+	}
+	switch {
+	case f.Path == "":
+		return true
+	case strings.HasSuffix(f.Path, "*"):
+		return strings.HasPrefix(path, strings.TrimSuffix(f.Path, "*"))
+	default:
+		return f.Path == path
+	}
+}
+
+func (f *Fault) write(w http.ResponseWriter) {
+	for k, values := range f.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Status)
+	w.Write([]byte(f.Body))
+}
@@ -0,0 +1,254 @@
+package goshoplazzatest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ztp130213/goshoplazza"
+)
+
+// RecorderMode tells a Recorder whether to record or replay interactions
+type RecorderMode int
+
+const (
+	// ModeReplay serves requests from the cassette only, failing requests
+	// that were not recorded
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests to Shoplazza and records the interactions
+	ModeRecord
+
+	// ModeAuto replays the cassette if it exists and records it otherwise
+	ModeAuto
+)
+
+// Cassette holds the interactions recorded by a Recorder
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request an interaction is matched on,
+// along with its redacted headers and body.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording Shoplazza interactions to a
+// cassette file, or replaying them from it. Plug it into a client with:
+//
+//	rec, err := goshoplazzatest.NewRecorder("testdata/orders.json", goshoplazzatest.ModeAuto)
+//	client.Client = rec.HTTPClient()
+//	defer rec.Save()
+//
+// Requests are matched on method, path and redacted query, with the
+// parameters sorted by key. Identical requests replay their recorded
+// responses in order, the last one being repeated once they are used up.
+type Recorder struct {
+	// Transport used to send requests while recording, defaults to
+	// http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Redacts credentials and customer PII from the recorded headers,
+	// queries and bodies, defaults to goshoplazza.DefaultRedactor
+	Redactor goshoplazza.Redactor
+
+	path      string
+	recording bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// NewRecorder returns a recorder for the cassette at path. In replay mode
+// the cassette must exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{path: path, Redactor: goshoplazza.DefaultRedactor}
+
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case mode == ModeRecord:
+		r.recording = true
+		return r, nil
+	case mode == ModeAuto && errors.Is(err, os.ErrNotExist):
+		r.recording = true
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("goshoplazzatest: invalid cassette %s: %v", path, err)
+	}
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Recording reports whether the recorder records interactions
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// HTTPClient returns an http client sending its requests through the recorder
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing when replaying.
+func (r *Recorder) Save() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.EscapedPath(),
+		Query:  r.Redactor.Query(req.URL.RawQuery),
+		Header: r.Redactor.Header(req.Header),
+		Body:   r.redactBody(reqBody),
+	}
+
+	if !r.recording {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !interaction.Request.matches(recorded) {
+			continue
+		}
+		match = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if match < 0 {
+		target := recorded.Path
+		if recorded.Query != "" {
+			target += "?" + recorded.Query
+		}
+		return nil, fmt.Errorf("goshoplazzatest: no recorded interaction for %s %s", recorded.Method, target)
+	}
+	r.replayed[match] = true
+
+	return r.cassette.Interactions[match].Response.toResponse(req), nil
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: r.Redactor.Header(resp.Header),
+			Body:   r.redactBody(body),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (rr RecordedRequest) matches(other RecordedRequest) bool {
+	return rr.Method == other.Method && rr.Path == other.Path && rr.Query == other.Query
+}
+
+func (rr RecordedResponse) toResponse(req *http.Request) *http.Response {
+	header := rr.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.Status, http.StatusText(rr.Status)),
+		StatusCode:    rr.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+// redactBody redacts a JSON body. Bodies that are not JSON are recorded as
+// they are.
+func (r *Recorder) redactBody(body []byte) string {
+	if clean, ok := r.Redactor.Body(body); ok {
+		return string(clean)
+	}
+	return string(body)
+}
@@ -0,0 +1,123 @@
+package goshoplazzatest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ztp130213/goshoplazza"
+	"github.com/ztp130213/goshoplazza/goshoplazzatest"
+)
+
+func TestRecorder(t *testing.T) {
+	srv := goshoplazzatest.NewServer()
+	defer srv.Close()
+	srv.AddOrder(goshoplazza.Order{
+		Name:            "#1001",
+		Email:           "jane@example.com",
+		ShippingAddress: &goshoplazza.Address{Name: "Jane Doe", City: "Paris"},
+	})
+
+	cassette := filepath.Join(t.TempDir(), "orders.json")
+	rec, err := goshoplazzatest.NewRecorder(cassette, goshoplazzatest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = srv.HTTPClient().Transport
+
+	client := srv.Client()
+	client.Client = rec.HTTPClient()
+	options := struct {
+		Email string `url:"email"`
+	}{"jane@example.com"}
+	recorded, err := client.Order.List(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"jane", "Jane", "Paris", "token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "#1001") {
+		t.Errorf("cassette lost the order name:\n%s", data)
+	}
+
+	// Replayed with the same redacted query, and decoding as before
+	rec, err = goshoplazzatest.NewRecorder(cassette, goshoplazzatest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Client = rec.HTTPClient()
+	replayed, err := client.Order.List(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != 1 || replayed[0].ID != recorded[0].ID || replayed[0].ShippingAddress == nil {
+		t.Errorf("replayed %+v, want the recorded order", replayed)
+	}
+}
+
+// roundTripFunc sends requests with a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorderRedactsOAuth(t *testing.T) {
+	oauth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"secrettoken","refresh_token":"secretrefresh","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer oauth.Close()
+	target, _ := url.Parse(oauth.URL)
+
+	cassette := filepath.Join(t.TempDir(), "oauth.json")
+	rec, err := goshoplazzatest.NewRecorder(cassette, goshoplazzatest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	app := goshoplazza.App{ApiKey: "apikey", ApiSecret: "secretsecret"}
+	token, err := app.GetAccessToken("theshop", "secretcode", goshoplazza.WithHTTPClient(rec.HTTPClient()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "secrettoken" {
+		t.Errorf("AccessToken = %q, want secrettoken", token.AccessToken)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secretsecret", "secretcode", "secrettoken", "secretrefresh"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "apikey") {
+		t.Errorf("cassette lost the client id:\n%s", data)
+	}
+}
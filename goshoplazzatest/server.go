@@ -0,0 +1,665 @@
+// Package goshoplazzatest provides an in-memory fake of the Shoplazza API for
+// testing code built on goshoplazza.
+//
+//	srv := goshoplazzatest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	product, err := client.Product.Create(goshoplazza.Product{Title: "Shirt"})
+package goshoplazzatest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ztp130213/goshoplazza"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 250
+)
+
+// Path prefix of every api path, with or without an api version
+var apiPathRegex = regexp.MustCompile(`^/openapi(/[0-9]{4}-[0-9]{2})?/`)
+
+// Server is a fake Shoplazza API storing products, variants, images, orders
+// and fulfillments in memory. It serves the same paths the goshoplazza
+// services call, and can be told to fail requests with InjectFault.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	products     *store[goshoplazza.Product]
+	variants     *store[goshoplazza.Variant]
+	images       *store[goshoplazza.Image]
+	orders       *store[goshoplazza.Order]
+	fulfillments *store[goshoplazza.Fulfillment]
+	faults       []*Fault
+}
+
+// NewServer starts a fake Shoplazza API server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		products:     newStore(productMeta),
+		variants:     newStore(variantMeta),
+		images:       newStore(imageMeta),
+		orders:       newStore(orderMeta),
+		fulfillments: newStore(fulfillmentMeta),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a goshoplazza client sending all requests to the fake server
+func (s *Server) Client(opts ...goshoplazza.Option) *goshoplazza.Client {
+	client := goshoplazza.NewClient(goshoplazza.App{}, "goshoplazzatest", "token", opts...)
+	client.Client = s.HTTPClient()
+	return client
+}
+
+// HTTPClient returns an http client rewriting every request to the fake
+// server, regardless of the shop it was addressed to.
+func (s *Server) HTTPClient() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{Transport: &rewriteTransport{target: target, next: http.DefaultTransport}}
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
+
+// AddProduct stores product, with its variants and images, as if it was
+// created through the API, and returns the stored copy.
+func (s *Server) AddProduct(product goshoplazza.Product) goshoplazza.Product {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.createProduct(product)
+}
+
+// AddOrder stores order, with its fulfillments, as if it was created
+// through the API, and returns the stored copy.
+func (s *Server) AddOrder(order goshoplazza.Order) goshoplazza.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.createOrder(order)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	loc := apiPathRegex.FindStringIndex(r.URL.Path)
+	if loc == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	// Some endpoints are addressed with a .json suffix, e.g. products/1.json
+	path := strings.TrimSuffix(strings.Trim(r.URL.Path[loc[1]:], "/"), ".json")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault := s.takeFault(r.Method, path); fault != nil {
+		fault.write(w)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &request{method: r.Method, segments: strings.Split(path, "/"), query: r.URL.Query(), body: body}
+	switch req.segments[0] {
+	case "products":
+		s.serveProducts(w, req)
+	case "variants":
+		s.serveVariants(w, req)
+	case "orders":
+		s.serveOrders(w, req)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// request is a parsed api request, segments being the path without the api
+// prefix
+type request struct {
+	method   string
+	segments []string
+	query    url.Values
+	body     []byte
+}
+
+// match reports whether the request has the given method and path, "*"
+// matching any single segment.
+func (r *request) match(method string, pattern ...string) bool {
+	if r.method != method || len(r.segments) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != r.segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *request) id(i int) goshoplazza.ID {
+	return goshoplazza.ID(r.segments[i])
+}
+
+func (s *Server) serveProducts(w http.ResponseWriter, r *request) {
+	switch {
+	case r.match("GET", "products"):
+		products := s.products.page(r.query, nil)
+		for i := range products {
+			s.fillProduct(&products[i])
+		}
+		writeJSON(w, http.StatusOK, goshoplazza.ProductsResource{Products: products})
+	case r.match("GET", "products", "count"):
+		writeCount(w, len(s.products.filter(r.query, nil)))
+	case r.match("POST", "products"):
+		resource := goshoplazza.ProductResource{}
+		if !decodeBody(w, r, &resource) || resource.Product == nil {
+			return
+		}
+		writeJSON(w, http.StatusCreated, goshoplazza.ProductResource{Product: s.createProduct(*resource.Product)})
+	case r.match("GET", "products", "*"):
+		if product, ok := s.products.get(r.id(1)); ok {
+			s.fillProduct(product)
+			writeJSON(w, http.StatusOK, goshoplazza.ProductResource{Product: product})
+			return
+		}
+		writeError(w, http.StatusNotFound, "Not Found")
+	case r.match("PUT", "products", "*"):
+		product, ok := s.products.get(r.id(1))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		resource := goshoplazza.ProductResource{Product: product}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		product.ID = r.id(1)
+		product.Variants, product.Images = nil, nil
+		s.products.update(product.ID, product, &product.UpdatedAt)
+		s.fillProduct(product)
+		writeJSON(w, http.StatusOK, goshoplazza.ProductResource{Product: product})
+	case r.match("DELETE", "products", "*"):
+		if !s.products.delete(r.id(1)) {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		for _, variant := range s.variants.filter(nil, byProduct(r.id(1), variantProductID)) {
+			s.variants.delete(variant.ID)
+		}
+		for _, image := range s.images.filter(nil, byProduct(r.id(1), imageProductID)) {
+			s.images.delete(image.ID)
+		}
+		writeJSON(w, http.StatusOK, struct{}{})
+	case len(r.segments) >= 3 && r.segments[2] == "variants":
+		s.serveProductVariants(w, r)
+	case len(r.segments) >= 3 && r.segments[2] == "images":
+		s.serveProductImages(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveProductVariants(w http.ResponseWriter, r *request) {
+	productID := r.id(1)
+	if _, ok := s.products.get(productID); !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	filter := byProduct(productID, variantProductID)
+
+	switch {
+	case r.match("GET", "products", "*", "variants"):
+		writeJSON(w, http.StatusOK, goshoplazza.VariantsResource{Variants: s.variants.page(r.query, filter)})
+	case r.match("GET", "products", "*", "variants", "count"):
+		writeCount(w, len(s.variants.filter(r.query, filter)))
+	case r.match("POST", "products", "*", "variants"):
+		resource := goshoplazza.VariantResource{}
+		if !decodeBody(w, r, &resource) || resource.Variant == nil {
+			return
+		}
+		variant := *resource.Variant
+		variant.ProductID = productID
+		writeJSON(w, http.StatusCreated, goshoplazza.VariantResource{Variant: s.createVariant(variant)})
+	case r.match("DELETE", "products", "*", "variants", "*"):
+		variant, ok := s.variants.get(r.id(4))
+		if !ok || variant.ProductID != productID {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		s.variants.delete(variant.ID)
+		writeJSON(w, http.StatusOK, struct{}{})
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveVariants(w http.ResponseWriter, r *request) {
+	switch {
+	case r.match("GET", "variants", "*"):
+		if variant, ok := s.variants.get(r.id(1)); ok {
+			writeJSON(w, http.StatusOK, goshoplazza.VariantResource{Variant: variant})
+			return
+		}
+		writeError(w, http.StatusNotFound, "Not Found")
+	case r.match("PUT", "variants", "*"):
+		variant, ok := s.variants.get(r.id(1))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		productID := variant.ProductID
+		resource := goshoplazza.VariantResource{Variant: variant}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		variant.ID, variant.ProductID = r.id(1), productID
+		s.variants.update(variant.ID, variant, &variant.UpdatedAt)
+		writeJSON(w, http.StatusOK, goshoplazza.VariantResource{Variant: variant})
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveProductImages(w http.ResponseWriter, r *request) {
+	productID := r.id(1)
+	if _, ok := s.products.get(productID); !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	filter := byProduct(productID, imageProductID)
+
+	// Images of other products are not found
+	var image *goshoplazza.Image
+	if len(r.segments) == 4 && r.segments[3] != "count" {
+		var ok bool
+		image, ok = s.images.get(r.id(3))
+		if !ok || image.ProductID != productID {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	switch {
+	case r.match("GET", "products", "*", "images"):
+		writeJSON(w, http.StatusOK, goshoplazza.ImagesResource{Images: s.images.page(r.query, filter)})
+	case r.match("GET", "products", "*", "images", "count"):
+		writeCount(w, len(s.images.filter(r.query, filter)))
+	case r.match("POST", "products", "*", "images"):
+		resource := goshoplazza.ImageResource{}
+		if !decodeBody(w, r, &resource) || resource.Image == nil {
+			return
+		}
+		image := *resource.Image
+		image.ProductID = productID
+		writeJSON(w, http.StatusCreated, goshoplazza.ImageResource{Image: s.createImage(image)})
+	case r.match("GET", "products", "*", "images", "*"):
+		writeJSON(w, http.StatusOK, goshoplazza.ImageResource{Image: image})
+	case r.match("PUT", "products", "*", "images", "*"):
+		resource := goshoplazza.ImageResource{Image: image}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		image.ID, image.ProductID = r.id(3), productID
+		s.images.update(image.ID, image, &image.UpdatedAt)
+		writeJSON(w, http.StatusOK, goshoplazza.ImageResource{Image: image})
+	case r.match("DELETE", "products", "*", "images", "*"):
+		s.images.delete(image.ID)
+		writeJSON(w, http.StatusOK, struct{}{})
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveOrders(w http.ResponseWriter, r *request) {
+	switch {
+	case r.match("GET", "orders"):
+		orders := s.orders.page(r.query, orderFilter(r.query))
+		for i := range orders {
+			s.fillOrder(&orders[i])
+		}
+		writeJSON(w, http.StatusOK, goshoplazza.OrdersResource{Orders: orders})
+	case r.match("GET", "orders", "count"):
+		writeCount(w, len(s.orders.filter(r.query, orderFilter(r.query))))
+	case r.match("POST", "orders"):
+		resource := goshoplazza.OrderResource{}
+		if !decodeBody(w, r, &resource) || resource.Order == nil {
+			return
+		}
+		writeJSON(w, http.StatusCreated, goshoplazza.OrderResource{Order: s.createOrder(*resource.Order)})
+	case r.match("GET", "orders", "*"):
+		if order, ok := s.orders.get(r.id(1)); ok {
+			s.fillOrder(order)
+			writeJSON(w, http.StatusOK, goshoplazza.OrderResource{Order: order})
+			return
+		}
+		writeError(w, http.StatusNotFound, "Not Found")
+	case r.match("PUT", "orders", "*"):
+		order, ok := s.orders.get(r.id(1))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		resource := goshoplazza.OrderResource{Order: order}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		order.ID = r.id(1)
+		order.Fulfillments = nil
+		s.orders.update(order.ID, order, &order.UpdatedAt)
+		s.fillOrder(order)
+		writeJSON(w, http.StatusOK, goshoplazza.OrderResource{Order: order})
+	case r.match("POST", "orders", "*", "cancel"), r.match("POST", "orders", "*", "close"), r.match("POST", "orders", "*", "open"):
+		order, ok := s.orders.get(r.id(1))
+		if !ok {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		s.transitionOrder(w, r, order)
+	case len(r.segments) >= 3 && r.segments[2] == "fulfillments":
+		s.serveOrderFulfillments(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) serveOrderFulfillments(w http.ResponseWriter, r *request) {
+	orderID := r.id(1)
+	if _, ok := s.orders.get(orderID); !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	filter := byOrder(orderID)
+
+	var fulfillment *goshoplazza.Fulfillment
+	if len(r.segments) >= 4 && r.segments[3] != "count" {
+		var ok bool
+		fulfillment, ok = s.fulfillments.get(r.id(3))
+		if !ok || fulfillment.OrderID != orderID {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	switch {
+	case r.match("GET", "orders", "*", "fulfillments"):
+		writeJSON(w, http.StatusOK, goshoplazza.FulfillmentsResource{Fulfillments: s.fulfillments.page(r.query, filter)})
+	case r.match("GET", "orders", "*", "fulfillments", "count"):
+		writeCount(w, len(s.fulfillments.filter(r.query, filter)))
+	case r.match("POST", "orders", "*", "fulfillments"):
+		// Fulfillments are created without the resource wrapper
+		resource := goshoplazza.FulfillmentResource{}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		if resource.Fulfillment == nil {
+			resource.Fulfillment = new(goshoplazza.Fulfillment)
+			if !decodeBody(w, r, resource.Fulfillment) {
+				return
+			}
+		}
+		created := *resource.Fulfillment
+		created.OrderID = orderID
+		writeJSON(w, http.StatusCreated, goshoplazza.FulfillmentResource{Fulfillment: s.createFulfillment(created)})
+	case r.match("GET", "orders", "*", "fulfillments", "*"):
+		writeJSON(w, http.StatusOK, goshoplazza.FulfillmentResource{Fulfillment: fulfillment})
+	case r.match("PUT", "orders", "*", "fulfillments", "*"):
+		resource := goshoplazza.FulfillmentResource{Fulfillment: fulfillment}
+		if !decodeBody(w, r, &resource) {
+			return
+		}
+		fulfillment.ID, fulfillment.OrderID = r.id(3), orderID
+		s.fulfillments.update(fulfillment.ID, fulfillment, &fulfillment.UpdatedAt)
+		writeJSON(w, http.StatusOK, goshoplazza.FulfillmentResource{Fulfillment: fulfillment})
+	case r.match("POST", "orders", "*", "fulfillments", "*", "complete"):
+		s.transitionFulfillment(w, fulfillment, "success")
+	case r.match("POST", "orders", "*", "fulfillments", "*", "open"):
+		s.transitionFulfillment(w, fulfillment, "open")
+	case r.match("POST", "orders", "*", "fulfillments", "*", "cancel"):
+		s.transitionFulfillment(w, fulfillment, "cancelled")
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// transitionOrder applies the cancel, close or open action of r to order
+func (s *Server) transitionOrder(w http.ResponseWriter, r *request, order *goshoplazza.Order) {
+	now := time.Now().UTC().Truncate(time.Second)
+	switch r.segments[2] {
+	case "cancel":
+		if order.CancelledAt != nil {
+			writeError(w, http.StatusUnprocessableEntity, "Order has already been cancelled")
+			return
+		}
+		options := goshoplazza.OrderCancelOptions{}
+		if !decodeBody(w, r, &options) {
+			return
+		}
+		order.CancelledAt = &now
+		order.CancelReason = options.Reason
+		order.ClosedAt = &now
+	case "close":
+		order.ClosedAt = &now
+	case "open":
+		order.ClosedAt = nil
+	}
+	order.Fulfillments = nil
+	s.orders.update(order.ID, order, &order.UpdatedAt)
+	s.fillOrder(order)
+	writeJSON(w, http.StatusOK, goshoplazza.OrderResource{Order: order})
+}
+
+func (s *Server) transitionFulfillment(w http.ResponseWriter, fulfillment *goshoplazza.Fulfillment, status string) {
+	fulfillment.Status = status
+	s.fulfillments.update(fulfillment.ID, fulfillment, &fulfillment.UpdatedAt)
+	writeJSON(w, http.StatusOK, goshoplazza.FulfillmentResource{Fulfillment: fulfillment})
+}
+
+func (s *Server) createProduct(product goshoplazza.Product) *goshoplazza.Product {
+	variants, images := product.Variants, product.Images
+	product.Variants, product.Images = nil, nil
+	created := s.products.create(product, func(p *goshoplazza.Product, id goshoplazza.ID, now *time.Time) {
+		p.ID, p.CreatedAt, p.UpdatedAt = id, now, now
+	})
+	for _, variant := range variants {
+		variant.ProductID = created.ID
+		s.createVariant(variant)
+	}
+	for _, image := range images {
+		image.ProductID = created.ID
+		s.createImage(image)
+	}
+	s.fillProduct(created)
+	return created
+}
+
+func (s *Server) createVariant(variant goshoplazza.Variant) *goshoplazza.Variant {
+	return s.variants.create(variant, func(v *goshoplazza.Variant, id goshoplazza.ID, now *time.Time) {
+		v.ID, v.CreatedAt, v.UpdatedAt = id, now, now
+	})
+}
+
+func (s *Server) createImage(image goshoplazza.Image) *goshoplazza.Image {
+	return s.images.create(image, func(i *goshoplazza.Image, id goshoplazza.ID, now *time.Time) {
+		i.ID, i.CreatedAt, i.UpdatedAt = id, now, now
+	})
+}
+
+func (s *Server) createOrder(order goshoplazza.Order) *goshoplazza.Order {
+	fulfillments := order.Fulfillments
+	order.Fulfillments = nil
+	created := s.orders.create(order, func(o *goshoplazza.Order, id goshoplazza.ID, now *time.Time) {
+		o.ID, o.CreatedAt, o.UpdatedAt = id, now, now
+	})
+	for _, fulfillment := range fulfillments {
+		fulfillment.OrderID = created.ID
+		s.createFulfillment(fulfillment)
+	}
+	s.fillOrder(created)
+	return created
+}
+
+func (s *Server) createFulfillment(fulfillment goshoplazza.Fulfillment) *goshoplazza.Fulfillment {
+	if fulfillment.Status == "" {
+		fulfillment.Status = "pending"
+	}
+	return s.fulfillments.create(fulfillment, func(f *goshoplazza.Fulfillment, id goshoplazza.ID, now *time.Time) {
+		f.ID, f.CreatedAt, f.UpdatedAt = id, now, now
+	})
+}
+
+// fillProduct sets the variants and images of a stored product copy
+func (s *Server) fillProduct(product *goshoplazza.Product) {
+	product.Variants = s.variants.filter(nil, byProduct(product.ID, variantProductID))
+	product.Images = s.images.filter(nil, byProduct(product.ID, imageProductID))
+}
+
+// fillOrder sets the fulfillments of a stored order copy
+func (s *Server) fillOrder(order *goshoplazza.Order) {
+	order.Fulfillments = s.fulfillments.filter(nil, byOrder(order.ID))
+}
+
+func variantProductID(v goshoplazza.Variant) goshoplazza.ID { return v.ProductID }
+func imageProductID(i goshoplazza.Image) goshoplazza.ID     { return i.ProductID }
+
+func byProduct[T any](productID goshoplazza.ID, productOf func(T) goshoplazza.ID) func(T) bool {
+	return func(item T) bool { return productOf(item) == productID }
+}
+
+func byOrder(orderID goshoplazza.ID) func(goshoplazza.Fulfillment) bool {
+	return func(f goshoplazza.Fulfillment) bool { return f.OrderID == orderID }
+}
+
+// orderFilter applies the filters of goshoplazza.OrderListOptions
+func orderFilter(query url.Values) func(goshoplazza.Order) bool {
+	status := query.Get("status")
+	financialStatus := query.Get("financial_status")
+	fulfillmentStatus := query.Get("fulfillment_status")
+	processedAtMin := parseTime(query.Get("processed_at_min"))
+	processedAtMax := parseTime(query.Get("processed_at_max"))
+
+	return func(order goshoplazza.Order) bool {
+		switch status {
+		case "", "any":
+		case "open":
+			if order.ClosedAt != nil || order.CancelledAt != nil {
+				return false
+			}
+		case "closed":
+			if order.ClosedAt == nil {
+				return false
+			}
+		case "cancelled":
+			if order.CancelledAt == nil {
+				return false
+			}
+		default:
+			return false
+		}
+		if financialStatus != "" && financialStatus != "any" && order.FinancialStatus != financialStatus {
+			return false
+		}
+		if fulfillmentStatus != "" && fulfillmentStatus != "any" && order.FulfillmentStatus != fulfillmentStatus {
+			return false
+		}
+		return inRange(order.ProcessedAt, processedAtMin, processedAtMax)
+	}
+}
+
+func decodeBody(w http.ResponseWriter, r *request, v interface{}) bool {
+	if err := json.Unmarshal(r.body, v); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeCount(w http.ResponseWriter, count int) {
+	writeJSON(w, http.StatusOK, struct {
+		Count int `json:"count"`
+	}{count})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, struct {
+		Errors string `json:"errors"`
+	}{message})
+}
+
+func parseTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func inRange(t, min, max *time.Time) bool {
+	if min != nil && (t == nil || t.Before(*min)) {
+		return false
+	}
+	if max != nil && (t == nil || t.After(*max)) {
+		return false
+	}
+	return true
+}
+
+func newID() goshoplazza.ID {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return goshoplazza.ID(fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]))
+}
+
+func pageParams(query url.Values) (page, limit int) {
+	page, _ = strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ = strconv.Atoi(query.Get("limit"))
+	if limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return page, limit
+}
@@ -0,0 +1,152 @@
+package goshoplazzatest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ztp130213/goshoplazza"
+	"github.com/ztp130213/goshoplazza/goshoplazzatest"
+)
+
+// fastRetries retries failed requests without waiting on the backoff
+var fastRetries = goshoplazza.WithRetryPolicy(goshoplazza.RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  time.Millisecond,
+})
+
+func TestRateLimited(t *testing.T) {
+	srv := goshoplazzatest.NewServer()
+	defer srv.Close()
+	product := srv.AddProduct(goshoplazza.Product{Title: "Shirt"})
+
+	srv.InjectFault(goshoplazzatest.RateLimited(1))
+	_, err := srv.Client().Product.Get(product.ID, nil)
+	var rateLimitErr goshoplazza.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("Get error = %v, want a RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 1 {
+		t.Errorf("RetryAfter = %d, want 1", rateLimitErr.RetryAfter)
+	}
+
+	// Retried once Retry-After elapsed, even with a tiny backoff
+	srv.InjectFault(goshoplazzatest.RateLimited(1))
+	start := time.Now()
+	got, err := srv.Client(fastRetries).Product.Get(product.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s of Retry-After", elapsed)
+	}
+	if got.ID != product.ID {
+		t.Errorf("got product %s, want %s", got.ID, product.ID)
+	}
+}
+
+func TestServerErrorRetry(t *testing.T) {
+	srv := goshoplazzatest.NewServer()
+	defer srv.Close()
+	product := srv.AddProduct(goshoplazza.Product{Title: "Shirt"})
+	client := srv.Client(fastRetries)
+
+	srv.InjectFault(goshoplazzatest.Fault{Method: http.MethodGet, Path: "products/*", Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := client.Product.Get(product.ID, nil); err != nil {
+		t.Errorf("Get after two server errors = %v, want success on the third attempt", err)
+	}
+
+	srv.InjectFault(goshoplazzatest.ServerError(http.StatusBadGateway))
+	_, err := client.Product.Create(goshoplazza.Product{Title: "Pants"})
+	var responseErr goshoplazza.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Status != http.StatusBadGateway {
+		t.Errorf("Create error = %v, want the 502 without retrying a POST", err)
+	}
+	if count, _ := client.Product.Count(nil); count != 1 {
+		t.Errorf("%d products, the failed create must not have been retried", count)
+	}
+
+	srv.InjectFault(goshoplazzatest.Fault{Status: http.StatusInternalServerError, Times: 3})
+	_, err = client.Product.Get(product.ID, nil)
+	var retryErr goshoplazza.RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 {
+		t.Errorf("Get error = %v, want a RetryError after 3 attempts", err)
+	}
+}
+
+func TestMalformedBody(t *testing.T) {
+	srv := goshoplazzatest.NewServer()
+	defer srv.Close()
+	product := srv.AddProduct(goshoplazza.Product{Title: "Shirt"})
+	client := srv.Client()
+
+	srv.InjectFault(goshoplazzatest.MalformedBody(http.StatusOK))
+	_, err := client.Product.Get(product.ID, nil)
+	var decodingErr goshoplazza.ResponseDecodingError
+	if !errors.As(err, &decodingErr) || decodingErr.Status != http.StatusOK {
+		t.Errorf("Get with a malformed 200 body error = %v, want a ResponseDecodingError", err)
+	}
+
+	// A malformed error body is still typed by its status
+	srv.InjectFault(goshoplazzatest.MalformedBody(http.StatusInternalServerError))
+	_, err = client.Product.Get(product.ID, nil)
+	var serverErr goshoplazza.ServerError
+	if !errors.As(err, &serverErr) || serverErr.Status != http.StatusInternalServerError {
+		t.Errorf("Get with a malformed 500 body error = %v, want a ServerError", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	srv := goshoplazzatest.NewServer()
+	defer srv.Close()
+
+	var want []goshoplazza.ID
+	for _, title := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		want = append(want, srv.AddProduct(goshoplazza.Product{Title: title}).ID)
+	}
+	client := srv.Client()
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		options *goshoplazza.ListOptions
+		want    []goshoplazza.ID
+	}{
+		{"page", &goshoplazza.ListOptions{Limit: 3}, want},
+		{"page exact", &goshoplazza.ListOptions{Limit: 7}, want},
+		{"since_id", &goshoplazza.ListOptions{Limit: 2, SinceID: want[0]}, want[1:]},
+		{"since_id last", &goshoplazza.ListOptions{Limit: 2, SinceID: want[6]}, nil},
+	}
+	for _, c := range cases {
+		products, err := client.Product.ListAll(ctx, c.options)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		var got []goshoplazza.ID
+		for _, product := range products {
+			got = append(got, product.ID)
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %d products, want %d", c.name, len(got), len(c.want))
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("%s: product %d = %s, want %s", c.name, i, got[i], c.want[i])
+			}
+		}
+	}
+
+	// A failing page stops the iteration with its error
+	srv.InjectFault(goshoplazzatest.Fault{Path: "products", Status: http.StatusServiceUnavailable})
+	it := client.Product.Iter(ctx, &goshoplazza.ListOptions{Limit: 3})
+	for it.Next() {
+	}
+	if it.Err() == nil {
+		t.Error("Iter over a failing page returned no error")
+	}
+}
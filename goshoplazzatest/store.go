@@ -0,0 +1,150 @@
+package goshoplazzatest
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ztp130213/goshoplazza"
+)
+
+// meta holds the fields of a stored resource used for filtering
+type meta struct {
+	id        goshoplazza.ID
+	createdAt *time.Time
+	updatedAt *time.Time
+}
+
+// store keeps resources of one type in creation order. Resources are stored
+// and returned by value, so callers never share them with the store.
+type store[T any] struct {
+	ids   []goshoplazza.ID
+	items map[goshoplazza.ID]T
+	meta  func(T) meta
+}
+
+func newStore[T any](meta func(T) meta) *store[T] {
+	return &store[T]{items: make(map[goshoplazza.ID]T), meta: meta}
+}
+
+func productMeta(p goshoplazza.Product) meta {
+	return meta{p.ID, p.CreatedAt, p.UpdatedAt}
+}
+
+func variantMeta(v goshoplazza.Variant) meta {
+	return meta{v.ID, v.CreatedAt, v.UpdatedAt}
+}
+
+func imageMeta(i goshoplazza.Image) meta {
+	return meta{i.ID, i.CreatedAt, i.UpdatedAt}
+}
+
+func orderMeta(o goshoplazza.Order) meta {
+	return meta{o.ID, o.CreatedAt, o.UpdatedAt}
+}
+
+func fulfillmentMeta(f goshoplazza.Fulfillment) meta {
+	return meta{f.ID, f.CreatedAt, f.UpdatedAt}
+}
+
+// create stores item after init assigned its id and timestamps, keeping an
+// id set by the caller.
+func (st *store[T]) create(item T, init func(item *T, id goshoplazza.ID, now *time.Time)) *T {
+	id := st.meta(item).id
+	if _, taken := st.items[id]; id == "" || taken {
+		id = newID()
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	init(&item, id, &now)
+
+	st.ids = append(st.ids, id)
+	st.items[id] = item
+	return &item
+}
+
+func (st *store[T]) get(id goshoplazza.ID) (*T, bool) {
+	item, ok := st.items[id]
+	if !ok {
+		return nil, false
+	}
+	return &item, true
+}
+
+// update replaces the stored resource, refreshing its updated_at timestamp
+func (st *store[T]) update(id goshoplazza.ID, item *T, updatedAt **time.Time) {
+	now := time.Now().UTC().Truncate(time.Second)
+	*updatedAt = &now
+	st.items[id] = *item
+}
+
+func (st *store[T]) delete(id goshoplazza.ID) bool {
+	if _, ok := st.items[id]; !ok {
+		return false
+	}
+	delete(st.items, id)
+	for i, stored := range st.ids {
+		if stored == id {
+			st.ids = append(st.ids[:i], st.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// filter returns the resources matching the common list options of query,
+// i.e. ids, since_id and the created_at/updated_at bounds, and keep.
+func (st *store[T]) filter(query url.Values, keep func(T) bool) []T {
+	ids := map[goshoplazza.ID]bool{}
+	for _, id := range strings.Split(query.Get("ids"), ",") {
+		if id != "" {
+			ids[goshoplazza.ID(id)] = true
+		}
+	}
+	sinceID := goshoplazza.ID(query.Get("since_id"))
+	createdAtMin := parseTime(query.Get("created_at_min"))
+	createdAtMax := parseTime(query.Get("created_at_max"))
+	updatedAtMin := parseTime(query.Get("updated_at_min"))
+	updatedAtMax := parseTime(query.Get("updated_at_max"))
+
+	// since_id returns the resources created after the given one
+	afterSince := sinceID == ""
+
+	items := []T{}
+	for _, id := range st.ids {
+		if !afterSince {
+			afterSince = id == sinceID
+			continue
+		}
+
+		item := st.items[id]
+		m := st.meta(item)
+		if len(ids) > 0 && !ids[m.id] {
+			continue
+		}
+		if !inRange(m.createdAt, createdAtMin, createdAtMax) || !inRange(m.updatedAt, updatedAtMin, updatedAtMax) {
+			continue
+		}
+		if keep != nil && !keep(item) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// page returns the requested page of the filtered resources. Pages are
+// numbered from 1, with since_id the first page starts after that resource.
+func (st *store[T]) page(query url.Values, keep func(T) bool) []T {
+	items := st.filter(query, keep)
+	page, limit := pageParams(query)
+
+	start := (page - 1) * limit
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
@@ -0,0 +1,64 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+var testApp = App{
+	ApiKey:      "apikey",
+	ApiSecret:   "hush",
+	RedirectUrl: "https://example.com/callback",
+	Scope:       "read_products",
+	Password:    "privateapppassword",
+}
+
+// rewriteTransport sends every request to target, regardless of the shop
+// it was addressed to
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newTestServer starts a server for handler and returns an http client
+// sending all requests to it
+func newTestServer(t *testing.T, handler http.Handler) *http.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+	return &http.Client{Transport: rewriteTransport{target}}
+}
+
+// newTestClient returns a client sending its requests to handler
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithHTTPClient(newTestServer(t, handler))}, opts...)
+	return NewClient(testApp, "theshop", "token", opts...)
+}
+
+// sentRequest is a request received by the server of newRecordingClient
+type sentRequest struct {
+	Method string
+	Path   string
+	Body   map[string]json.RawMessage
+}
+
+// newRecordingClient returns a client whose requests are recorded in sent
+// and answered with response
+func newRecordingClient(t *testing.T, response string, sent *sentRequest) *Client {
+	t.Helper()
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*sent = sentRequest{Method: r.Method, Path: r.URL.Path}
+		json.NewDecoder(r.Body).Decode(&sent.Body)
+		w.Write([]byte(response))
+	}))
+}
@@ -0,0 +1,62 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrEmptyID is returned when a request is made without the ID of its
+// resource, or of the parent resource, instead of sending it to another path.
+var ErrEmptyID = errors.New("goshoplazza: empty resource id")
+
+// ID identifies a Shoplazza resource. Shoplazza uses UUID style string IDs,
+// yet some endpoints return plain numbers, so an ID decodes from both JSON
+// strings and numbers. It always encodes as a JSON string.
+type ID string
+
+func (id ID) String() string {
+	return string(id)
+}
+
+// UnmarshalJSON decodes an ID from a JSON string or number
+func (id *ID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*id = ""
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*id = ID(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("goshoplazza: invalid id %s", data)
+	}
+	*id = ID(n.String())
+	return nil
+}
+
+// escape returns the ID escaped for use as a path segment
+func (id ID) escape() string {
+	return url.PathEscape(string(id))
+}
+
+// requireIDs returns ErrEmptyID if any of ids is empty
+func requireIDs(ids ...ID) error {
+	for _, id := range ids {
+		if id == "" {
+			return ErrEmptyID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package goshoplazza
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEmptyID(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+
+	errOf := func(_ interface{}, err error) error { return err }
+	cases := []struct {
+		name string
+		call func() error
+	}{
+		{"Product.Get", func() error { return errOf(client.Product.Get("", nil)) }},
+		{"Order.Update", func() error { return errOf(client.Order.Update(Order{Note: "note"})) }},
+		{"Order.Cancel", func() error { return errOf(client.Order.Cancel("", OrderCancelOptions{})) }},
+		{"Order.Close", func() error { return errOf(client.Order.Close("")) }},
+		{"Order.Open", func() error { return errOf(client.Order.Open("")) }},
+		{"Refund.Create", func() error { return errOf(client.Refund.Create("", Refund{})) }},
+		{"Refund.Calculate", func() error { return errOf(client.Refund.Calculate("", Refund{})) }},
+		{"Refund.List", func() error { return errOf(client.Refund.List("", nil)) }},
+		{"Transaction.Create", func() error { return errOf(client.Transaction.Create("", Transaction{})) }},
+		{"Transaction.List", func() error { return errOf(client.Transaction.List("", nil)) }},
+		{"Transaction.Count", func() error { return errOf(client.Transaction.Count("", nil)) }},
+		{"Order.CompleteFulfillment", func() error { return errOf(client.Order.CompleteFulfillment("1", "")) }},
+		{"Order.TransitionFulfillment", func() error { return errOf(client.Order.TransitionFulfillment("1", "")) }},
+		{"Order.CancelFulfillment", func() error { return errOf(client.Order.CancelFulfillment("1", "")) }},
+		{"Order.ListFulfillments without order", func() error { return errOf(client.Order.ListFulfillments("", nil)) }},
+		{"Order.CreateFulfillment without order", func() error { return errOf(client.Order.CreateFulfillment("", Fulfillment{})) }},
+		{"Order.CompleteFulfillment without order", func() error { return errOf(client.Order.CompleteFulfillment("", "1")) }},
+		{"DraftOrder.SendInvoice", func() error { return errOf(client.DraftOrder.SendInvoice("", DraftOrderInvoice{})) }},
+		{"Customer.ListOrders", func() error { return errOf(client.Customer.ListOrders("", nil)) }},
+		{"CustomerAddress.List", func() error { return errOf(client.CustomerAddress.List("", nil)) }},
+		{"CustomerAddress.Create", func() error { return errOf(client.CustomerAddress.Create("", CustomerAddress{})) }},
+		{"CustomerAddress.SetDefault", func() error { return errOf(client.CustomerAddress.SetDefault("1", "")) }},
+		{"Variant.List", func() error { return errOf(client.Variant.List("", nil)) }},
+		{"Variant.Count", func() error { return errOf(client.Variant.Count("", nil)) }},
+		{"Variant.Create", func() error { return errOf(client.Variant.Create("", Variant{})) }},
+		{"Image.Get", func() error { return errOf(client.Image.Get("1", "", nil)) }},
+		{"Image.List", func() error { return errOf(client.Image.List("", nil)) }},
+		{"Image.Count", func() error { return errOf(client.Image.Count("", nil)) }},
+		{"Image.Create", func() error { return errOf(client.Image.Create("", Image{})) }},
+		{"SmartCollection.Reorder", func() error { return client.SmartCollection.Reorder("", []ID{"1"}) }},
+		{"Webhook.Delete", func() error { return client.Webhook.Delete("") }},
+		{"Order.ListMetafields without order", func() error { return errOf(client.Order.ListMetafields("", nil)) }},
+		{"Order.CountMetafields without order", func() error { return errOf(client.Order.CountMetafields("", nil)) }},
+		{"Product.CreateMetafield without product", func() error { return errOf(client.Product.CreateMetafield("", Metafield{})) }},
+		{"Product.GetMetafield without product", func() error { return errOf(client.Product.GetMetafield("", "1", nil)) }},
+		{"Product.DeleteMetafield without product", func() error { return client.Product.DeleteMetafield("", "1") }},
+	}
+	for _, c := range cases {
+		if err := c.call(); !errors.Is(err, ErrEmptyID) {
+			t.Errorf("%s with an empty ID error = %v, want %v", c.name, err, ErrEmptyID)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("%d requests sent for empty IDs, want none", requests)
+	}
+
+	// The shop metafields have no resource ID
+	if _, err := client.Metafield.List(nil); err != nil {
+		t.Errorf("Metafield.List of the shop error = %v", err)
+	}
+}
+
+func TestIDEscapedInPath(t *testing.T) {
+	var path string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write([]byte(`{"variant":{"id":"a/b"}}`))
+	}))
+
+	if _, err := client.Variant.Get("a/b", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "/openapi/variants/a%2Fb.json"; path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+}
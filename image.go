@@ -1,6 +1,7 @@
 package goshoplazza
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -9,12 +10,18 @@ import (
 // of the Shopify API.
 // See https://help.shopify.com/api/reference/product_image
 type ImageService interface {
-	List(int64, interface{}) ([]Image, error)
-	Count(int64, interface{}) (int, error)
-	Get(int64, int64, interface{}) (*Image, error)
-	Create(int64, Image) (*Image, error)
-	Update(int64, Image) (*Image, error)
-	Delete(int64, int64) error
+	List(ID, interface{}) ([]Image, error)
+	ListWithContext(context.Context, ID, interface{}) ([]Image, error)
+	Count(ID, interface{}) (int, error)
+	CountWithContext(context.Context, ID, interface{}) (int, error)
+	Get(ID, ID, interface{}) (*Image, error)
+	GetWithContext(context.Context, ID, ID, interface{}) (*Image, error)
+	Create(ID, Image) (*Image, error)
+	CreateWithContext(context.Context, ID, Image) (*Image, error)
+	Update(ID, Image) (*Image, error)
+	UpdateWithContext(context.Context, ID, Image) (*Image, error)
+	Delete(ID, ID) error
+	DeleteWithContext(context.Context, ID, ID) error
 }
 
 // ImageServiceOp handles communication with the image related methods of
@@ -25,15 +32,15 @@ type ImageServiceOp struct {
 
 // Image represents a Shopify product's image.
 type Image struct {
-	ID         string     `json:"id,omitempty"`
-	ProductID  string     `json:"product_id,omitempty"`
-	Position   int        `json:"position,omitempty"`
-	CreatedAt  *time.Time `json:"created_at,omitempty"`
-	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
-	Width      interface{}      `json:"width,omitempty"` // 有两种类型的值 220 和 "220", 使用时注意asset类型并做转换
-	Height     interface{}      `json:"height,omitempty"`// 有两种类型的值 220 和 "220", 使用时注意asset类型并做转换
-	Src        string     `json:"src,omitempty"`
-	Alt        string     `json:"alt,omitempty"`
+	ID        ID         `json:"id,omitempty"`
+	ProductID ID         `json:"product_id,omitempty"`
+	Position  FlexInt    `json:"position,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Width     FlexInt    `json:"width,omitempty"`  // 有两种类型的值 220 和 "220"
+	Height    FlexInt    `json:"height,omitempty"` // 有两种类型的值 220 和 "220"
+	Src       string     `json:"src,omitempty"`
+	Alt       string     `json:"alt,omitempty"`
 }
 
 // ImageResource represents the result form the products/X/images/Y.json endpoint
@@ -47,24 +54,48 @@ type ImagesResource struct {
 }
 
 // List images
-func (s *ImageServiceOp) List(productID int64, options interface{}) ([]Image, error) {
-	path := fmt.Sprintf("%s/%s/%d/images.json", globalApiPathPrefix, productsBasePath, productID)
+func (s *ImageServiceOp) List(productID ID, options interface{}) ([]Image, error) {
+	return s.ListWithContext(context.Background(), productID, options)
+}
+
+// ListWithContext lists images using ctx for the request
+func (s *ImageServiceOp) ListWithContext(ctx context.Context, productID ID, options interface{}) ([]Image, error) {
+	if err := requireIDs(productID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/images.json", s.client.pathPrefix, productsBasePath, productID.escape())
 	resource := new(ImagesResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Images, err
 }
 
 // Count images
-func (s *ImageServiceOp) Count(productID int64, options interface{}) (int, error) {
-	path := fmt.Sprintf("%s/%s/%d/images/count.json", globalApiPathPrefix, productsBasePath, productID)
-	return s.client.Count(path, options)
+func (s *ImageServiceOp) Count(productID ID, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), productID, options)
+}
+
+// CountWithContext counts images using ctx for the request
+func (s *ImageServiceOp) CountWithContext(ctx context.Context, productID ID, options interface{}) (int, error) {
+	if err := requireIDs(productID); err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/images/count.json", s.client.pathPrefix, productsBasePath, productID.escape())
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual image
-func (s *ImageServiceOp) Get(productID int64, imageID int64, options interface{}) (*Image, error) {
-	path := fmt.Sprintf("%s/%s/%d/images/%d.json", globalApiPathPrefix, productsBasePath, productID, imageID)
+func (s *ImageServiceOp) Get(productID ID, imageID ID, options interface{}) (*Image, error) {
+	return s.GetWithContext(context.Background(), productID, imageID, options)
+}
+
+// GetWithContext gets an individual image using ctx for the request
+func (s *ImageServiceOp) GetWithContext(ctx context.Context, productID ID, imageID ID, options interface{}) (*Image, error) {
+	if err := requireIDs(productID, imageID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/images/%s.json", s.client.pathPrefix, productsBasePath, productID.escape(), imageID.escape())
 	resource := new(ImageResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Image, err
 }
 
@@ -81,24 +112,48 @@ func (s *ImageServiceOp) Get(productID int64, imageID int64, options interface{}
 // Shopify will take the attachment.
 //
 // Shopify will accept Image.Attachment without Image.Filename.
-func (s *ImageServiceOp) Create(productID int64, image Image) (*Image, error) {
-	path := fmt.Sprintf("%s/%s/%d/images.json", globalApiPathPrefix, productsBasePath, productID)
+func (s *ImageServiceOp) Create(productID ID, image Image) (*Image, error) {
+	return s.CreateWithContext(context.Background(), productID, image)
+}
+
+// CreateWithContext creates a new image using ctx for the request
+func (s *ImageServiceOp) CreateWithContext(ctx context.Context, productID ID, image Image) (*Image, error) {
+	if err := requireIDs(productID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/images.json", s.client.pathPrefix, productsBasePath, productID.escape())
 	wrappedData := ImageResource{Image: &image}
 	resource := new(ImageResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Image, err
 }
 
 // Update an existing image
-func (s *ImageServiceOp) Update(productID int64, image Image) (*Image, error) {
-	path := fmt.Sprintf("%s/%s/%d/images/%d.json", globalApiPathPrefix, productsBasePath, productID, image.ID)
+func (s *ImageServiceOp) Update(productID ID, image Image) (*Image, error) {
+	return s.UpdateWithContext(context.Background(), productID, image)
+}
+
+// UpdateWithContext updates an existing image using ctx for the request
+func (s *ImageServiceOp) UpdateWithContext(ctx context.Context, productID ID, image Image) (*Image, error) {
+	if err := requireIDs(productID, image.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/images/%s.json", s.client.pathPrefix, productsBasePath, productID.escape(), image.ID.escape())
 	wrappedData := ImageResource{Image: &image}
 	resource := new(ImageResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Image, err
 }
 
 // Delete an existing image
-func (s *ImageServiceOp) Delete(productID int64, imageID int64) error {
-	return s.client.Delete(fmt.Sprintf("%s/%s/%d/images/%d.json", globalApiPathPrefix, productsBasePath, productID, imageID))
-}
\ No newline at end of file
+func (s *ImageServiceOp) Delete(productID ID, imageID ID) error {
+	return s.DeleteWithContext(context.Background(), productID, imageID)
+}
+
+// DeleteWithContext deletes an existing image using ctx for the request
+func (s *ImageServiceOp) DeleteWithContext(ctx context.Context, productID ID, imageID ID) error {
+	if err := requireIDs(productID, imageID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s/images/%s.json", s.client.pathPrefix, productsBasePath, productID.escape(), imageID.escape()))
+}
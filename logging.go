@@ -0,0 +1,152 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Bodies are read up to this size to be logged. Larger bodies are omitted,
+// they cannot be redacted without reading them entirely.
+const maxLogBodyRead = 1 << 20
+
+// WithLogger optionally logs every request of the client to logger, with
+// the method, path, query, status, latency and attempt of the request, and
+// the error message returned by Shoplazza if any.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithBodyLogging optionally adds the headers and up to maxBytes of the
+// request and response bodies to the logs of WithLogger. Credentials and
+// customer PII such as emails, phone numbers and addresses are redacted,
+// bodies that are not JSON or larger than 1MB are omitted.
+func WithBodyLogging(maxBytes int) Option {
+	return func(c *Client) {
+		c.logBodyBytes = maxBytes
+	}
+}
+
+// requestLog collects what is logged about a single attempt of a request
+type requestLog struct {
+	logger       *slog.Logger
+	maxBodyBytes int
+	req          *http.Request
+	attempt      int
+	start        time.Time
+	status       int
+	reqBody      []byte
+	respBody     []byte
+	respHeader   http.Header
+}
+
+func (c *Client) startRequestLog(req *http.Request, attempt int) *requestLog {
+	l := &requestLog{
+		logger:       c.logger,
+		maxBodyBytes: c.logBodyBytes,
+		req:          req,
+		attempt:      attempt,
+		start:        time.Now(),
+	}
+	if l.maxBodyBytes > 0 && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			l.reqBody, _ = ioutil.ReadAll(io.LimitReader(body, maxLogBodyRead+1))
+			body.Close()
+		}
+	}
+	return l
+}
+
+// response records the response when bodies are logged, reading at most
+// maxLogBodyRead bytes of its body and putting them back in front of the
+// unread remainder.
+func (l *requestLog) response(resp *http.Response) {
+	l.status = resp.StatusCode
+	if l.maxBodyBytes <= 0 {
+		return
+	}
+
+	l.respHeader = resp.Header
+	head, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxLogBodyRead+1))
+	resp.Body = readCloser{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err == nil {
+		l.respBody = head
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (l *requestLog) finish(err error) {
+	attrs := []slog.Attr{
+		slog.String("method", l.req.Method),
+		slog.String("path", l.req.URL.EscapedPath()),
+		slog.String("query", DefaultRedactor.Query(l.req.URL.RawQuery)),
+		slog.Int("attempt", l.attempt),
+		slog.Duration("latency", time.Since(l.start)),
+	}
+	if l.status != 0 {
+		attrs = append(attrs, slog.Int("status", l.status))
+	}
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+
+		var responseErr ResponseError
+		if errors.As(err, &responseErr) && len(responseErr.Errors) > 0 {
+			attrs = append(attrs, slog.Any("errors", responseErr.Errors))
+		}
+	}
+
+	if l.maxBodyBytes > 0 {
+		attrs = append(attrs,
+			slog.Any("request_header", DefaultRedactor.Header(l.req.Header)),
+			slog.String("request_body", redactBody(l.reqBody, l.maxBodyBytes)),
+		)
+		if l.respHeader != nil {
+			attrs = append(attrs,
+				slog.Any("response_header", DefaultRedactor.Header(l.respHeader)),
+				slog.String("response_body", redactBody(l.respBody, l.maxBodyBytes)),
+			)
+		}
+	}
+
+	ctx := l.req.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.logger.LogAttrs(ctx, level, "shoplazza request", attrs...)
+}
+
+// redactBody returns the body with its PII fields redacted, truncated to
+// maxBytes. Bodies that are not JSON, or too large to be redacted, are
+// omitted.
+func redactBody(body []byte, maxBytes int) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(body) > maxLogBodyRead {
+		return fmt.Sprintf("[body over %d bytes omitted]", maxLogBodyRead)
+	}
+	body, ok := DefaultRedactor.Body(body)
+	if !ok {
+		return "[non-JSON body omitted]"
+	}
+
+	if len(body) > maxBytes {
+		return string(body[:maxBytes]) + "..."
+	}
+	return string(body)
+}
@@ -0,0 +1,119 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords returns the records logged to buf by a slog JSON handler
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]interface{}
+		if err := dec.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestBodyLogging(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		wantLogged  string
+	}{
+		{"json", "application/json", `{"customer":{"id":"1","email":"jane@example.com"}}`, `{"customer":{"email":"REDACTED","id":"1"}}`},
+		{"truncated", "application/json", `{"customer":{"id":"1","note":"0123456789012345678901234567890123456789"}}`, `{"customer":{"id":"1","note":"0123456789012345678901234567890123...`},
+		{"html", "text/html", `<html>jane@example.com</html>`, "[non-JSON body omitted]"},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", c.contentType)
+			w.Write([]byte(c.body))
+		}))
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))(client)
+		WithBodyLogging(64)(client)
+
+		client.Get("customers/1", nil, nil)
+
+		records := logRecords(t, &buf)
+		if len(records) != 1 {
+			t.Fatalf("%s: %d log records, want 1", c.name, len(records))
+		}
+		if got := records[0]["response_body"]; got != c.wantLogged {
+			t.Errorf("%s: response_body = %q, want %q", c.name, got, c.wantLogged)
+		}
+	}
+}
+
+func TestBodyLoggingLargeBody(t *testing.T) {
+	var body strings.Builder
+	body.WriteString(`{"products":[`)
+	count := 0
+	for body.Len() <= maxLogBodyRead {
+		if count > 0 {
+			body.WriteString(",")
+		}
+		count++
+		fmt.Fprintf(&body, `{"id":"%d","title":"Shirt"}`, count)
+	}
+	body.WriteString(`]}`)
+
+	var buf bytes.Buffer
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body.String()))
+	}))
+	WithLogger(slog.New(slog.NewJSONHandler(&buf, nil)))(client)
+	WithBodyLogging(1024)(client)
+
+	products, err := client.Product.List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != count {
+		t.Errorf("decoded %d products, want %d, the unread remainder of the body was lost", len(products), count)
+	}
+
+	records := logRecords(t, &buf)
+	if len(records) != 1 || !strings.Contains(records[0]["response_body"].(string), "omitted") {
+		t.Errorf("records = %v, want the large body omitted", records)
+	}
+}
+
+func TestBodyLoggingRedactsOAuth(t *testing.T) {
+	httpClient := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"secrettoken","refresh_token":"secretrefresh","token_type":"Bearer","expires_in":3600}`))
+	}))
+
+	var buf bytes.Buffer
+	_, err := testApp.GetAccessToken("theshop", "secretcode",
+		WithHTTPClient(httpClient),
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))),
+		WithBodyLogging(1024),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "request_body") || !strings.Contains(logged, "response_body") {
+		t.Fatalf("log = %s, want the bodies logged", logged)
+	}
+	for _, secret := range []string{testApp.ApiSecret, "secretcode", "secrettoken", "secretrefresh"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("log contains %q: %s", secret, logged)
+		}
+	}
+}
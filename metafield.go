@@ -0,0 +1,347 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Value types of a metafield
+const (
+	MetafieldValueTypeString  = "string"
+	MetafieldValueTypeInteger = "integer"
+	MetafieldValueTypeJSON    = "json_string"
+)
+
+// MetafieldService is an interface for interfacing with the metafield endpoints
+// of the Shoplazza API.
+type MetafieldService interface {
+	List(interface{}) ([]Metafield, error)
+	ListWithContext(context.Context, interface{}) ([]Metafield, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Metafield, error)
+	GetWithContext(context.Context, ID, interface{}) (*Metafield, error)
+	Create(Metafield) (*Metafield, error)
+	CreateWithContext(context.Context, Metafield) (*Metafield, error)
+	Update(Metafield) (*Metafield, error)
+	UpdateWithContext(context.Context, Metafield) (*Metafield, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	Upsert(Metafield) (*Metafield, error)
+	UpsertWithContext(context.Context, Metafield) (*Metafield, error)
+}
+
+// MetafieldsService is an interface for other Shoplazza resources
+// to interface with the metafield endpoints of the Shoplazza API.
+type MetafieldsService interface {
+	ListMetafields(ID, interface{}) ([]Metafield, error)
+	ListMetafieldsWithContext(context.Context, ID, interface{}) ([]Metafield, error)
+	CountMetafields(ID, interface{}) (int, error)
+	CountMetafieldsWithContext(context.Context, ID, interface{}) (int, error)
+	GetMetafield(ID, ID, interface{}) (*Metafield, error)
+	GetMetafieldWithContext(context.Context, ID, ID, interface{}) (*Metafield, error)
+	CreateMetafield(ID, Metafield) (*Metafield, error)
+	CreateMetafieldWithContext(context.Context, ID, Metafield) (*Metafield, error)
+	UpdateMetafield(ID, Metafield) (*Metafield, error)
+	UpdateMetafieldWithContext(context.Context, ID, Metafield) (*Metafield, error)
+	DeleteMetafield(ID, ID) error
+	DeleteMetafieldWithContext(context.Context, ID, ID) error
+	UpsertMetafield(ID, Metafield) (*Metafield, error)
+	UpsertMetafieldWithContext(context.Context, ID, Metafield) (*Metafield, error)
+}
+
+// MetafieldServiceOp handles communication with the metafield
+// related methods of the Shoplazza API. Without a resource it handles the
+// metafields of the shop.
+type MetafieldServiceOp struct {
+	client     *Client
+	resource   string
+	resourceID ID
+}
+
+// requireIDs returns ErrEmptyID if any of ids is empty, or if the service
+// handles the metafields of a resource without its ID
+func (s *MetafieldServiceOp) requireIDs(ids ...ID) error {
+	if s.resource != "" {
+		ids = append(ids, s.resourceID)
+	}
+	return requireIDs(ids...)
+}
+
+// Metafield represents a Shoplazza metafield.
+type Metafield struct {
+	ID            ID          `json:"id,omitempty"`
+	Namespace     string      `json:"namespace,omitempty"`
+	Key           string      `json:"key,omitempty"`
+	Value         interface{} `json:"value,omitempty"`
+	ValueType     string      `json:"value_type,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	OwnerId       ID          `json:"owner_id,omitempty"`
+	OwnerResource string      `json:"owner_resource,omitempty"`
+	CreatedAt     *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time  `json:"updated_at,omitempty"`
+}
+
+// MetafieldListOptions can be used for filtering metafields on a List or
+// Count request.
+type MetafieldListOptions struct {
+	Page         int       `url:"page,omitempty"`
+	Limit        int       `url:"limit,omitempty"`
+	SinceID      ID        `url:"since_id,omitempty"`
+	Namespace    string    `url:"namespace,omitempty"`
+	Key          string    `url:"key,omitempty"`
+	ValueType    string    `url:"value_type,omitempty"`
+	CreatedAtMin time.Time `url:"created_at_min,omitempty"`
+	CreatedAtMax time.Time `url:"created_at_max,omitempty"`
+	UpdatedAtMin time.Time `url:"updated_at_min,omitempty"`
+	UpdatedAtMax time.Time `url:"updated_at_max,omitempty"`
+	Fields       string    `url:"fields,omitempty"`
+}
+
+// MetafieldResource represents the result from the metafields/X endpoint
+type MetafieldResource struct {
+	Metafield *Metafield `json:"metafield"`
+}
+
+// MetafieldsResource represents the result from the metafields endpoint
+type MetafieldsResource struct {
+	Metafields []Metafield `json:"metafields"`
+}
+
+// NewStringMetafield returns a metafield holding a string value
+func NewStringMetafield(namespace, key, value string) Metafield {
+	return Metafield{
+		Namespace: namespace,
+		Key:       key,
+		Value:     value,
+		ValueType: MetafieldValueTypeString,
+	}
+}
+
+// NewIntegerMetafield returns a metafield holding an integer value
+func NewIntegerMetafield(namespace, key string, value int64) Metafield {
+	return Metafield{
+		Namespace: namespace,
+		Key:       key,
+		Value:     value,
+		ValueType: MetafieldValueTypeInteger,
+	}
+}
+
+// NewJSONMetafield returns a metafield holding v encoded as JSON
+func NewJSONMetafield(namespace, key string, v interface{}) (Metafield, error) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return Metafield{}, err
+	}
+	return Metafield{
+		Namespace: namespace,
+		Key:       key,
+		Value:     string(value),
+		ValueType: MetafieldValueTypeJSON,
+	}, nil
+}
+
+// UnmarshalJSON decodes a metafield, keeping a number value as a
+// json.Number so that large integers are not rounded to a float64
+func (m *Metafield) UnmarshalJSON(data []byte) error {
+	type metafield Metafield
+	var raw struct {
+		metafield
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*m = Metafield(raw.metafield)
+	m.Value = nil
+	if len(raw.Value) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw.Value))
+	decoder.UseNumber()
+	return decoder.Decode(&m.Value)
+}
+
+// StringValue returns the value of the metafield as a string
+func (m Metafield) StringValue() string {
+	switch value := m.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	return fmt.Sprint(m.Value)
+}
+
+// IntegerValue returns the value of an integer metafield, which Shoplazza
+// may send as a number or a string. Values that are not integers, e.g.
+// 1.5, return an error.
+func (m Metafield) IntegerValue() (int64, error) {
+	switch value := m.Value.(type) {
+	case int64:
+		return value, nil
+	case int:
+		return int64(value), nil
+	case float64:
+		// Set by hand, decoded values are json.Number
+		return strconv.ParseInt(strconv.FormatFloat(value, 'f', -1, 64), 10, 64)
+	case json.Number:
+		return strconv.ParseInt(value.String(), 10, 64)
+	case string:
+		return strconv.ParseInt(value, 10, 64)
+	}
+	return 0, fmt.Errorf("goshoplazza: metafield %s.%s is not an integer: %v", m.Namespace, m.Key, m.Value)
+}
+
+// JSONValue decodes the value of a JSON metafield into v
+func (m Metafield) JSONValue(v interface{}) error {
+	if value, ok := m.Value.(string); ok {
+		return json.Unmarshal([]byte(value), v)
+	}
+	// Already decoded by Shoplazza, round trip it into v
+	data, err := json.Marshal(m.Value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// List metafields
+func (s *MetafieldServiceOp) List(options interface{}) ([]Metafield, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists metafields using ctx for the request
+func (s *MetafieldServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Metafield, error) {
+	if err := s.requireIDs(); err != nil {
+		return nil, err
+	}
+	path := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	resource := new(MetafieldsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Metafields, err
+}
+
+// Count metafields
+func (s *MetafieldServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts metafields using ctx for the request
+func (s *MetafieldServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	if err := s.requireIDs(); err != nil {
+		return 0, err
+	}
+	prefix := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/count", prefix)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual metafield
+func (s *MetafieldServiceOp) Get(metafieldID ID, options interface{}) (*Metafield, error) {
+	return s.GetWithContext(context.Background(), metafieldID, options)
+}
+
+// GetWithContext gets an individual metafield using ctx for the request
+func (s *MetafieldServiceOp) GetWithContext(ctx context.Context, metafieldID ID, options interface{}) (*Metafield, error) {
+	if err := s.requireIDs(metafieldID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s", prefix, metafieldID.escape())
+	resource := new(MetafieldResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Metafield, err
+}
+
+// Create a new metafield
+func (s *MetafieldServiceOp) Create(metafield Metafield) (*Metafield, error) {
+	return s.CreateWithContext(context.Background(), metafield)
+}
+
+// CreateWithContext creates a new metafield using ctx for the request
+func (s *MetafieldServiceOp) CreateWithContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	if err := s.requireIDs(); err != nil {
+		return nil, err
+	}
+	path := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Update an existing metafield
+func (s *MetafieldServiceOp) Update(metafield Metafield) (*Metafield, error) {
+	return s.UpdateWithContext(context.Background(), metafield)
+}
+
+// UpdateWithContext updates an existing metafield using ctx for the request
+func (s *MetafieldServiceOp) UpdateWithContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	if err := s.requireIDs(metafield.ID); err != nil {
+		return nil, err
+	}
+	prefix := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	path := fmt.Sprintf("%s/%s", prefix, metafield.ID.escape())
+	wrappedData := MetafieldResource{Metafield: &metafield}
+	resource := new(MetafieldResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Metafield, err
+}
+
+// Delete an existing metafield
+func (s *MetafieldServiceOp) Delete(metafieldID ID) error {
+	return s.DeleteWithContext(context.Background(), metafieldID)
+}
+
+// DeleteWithContext deletes an existing metafield using ctx for the request
+func (s *MetafieldServiceOp) DeleteWithContext(ctx context.Context, metafieldID ID) error {
+	if err := s.requireIDs(metafieldID); err != nil {
+		return err
+	}
+	prefix := s.client.MetafieldPathPrefix(s.resource, s.resourceID)
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s", prefix, metafieldID.escape()))
+}
+
+// ErrMetafieldKey is returned by Upsert for a metafield without a namespace
+// or key, which would match unrelated metafields.
+var ErrMetafieldKey = errors.New("goshoplazza: metafield namespace and key are required")
+
+// Upsert updates the metafield with the same namespace and key, or creates
+// it if there is none.
+func (s *MetafieldServiceOp) Upsert(metafield Metafield) (*Metafield, error) {
+	return s.UpsertWithContext(context.Background(), metafield)
+}
+
+// UpsertWithContext upserts a metafield using ctx for the requests
+func (s *MetafieldServiceOp) UpsertWithContext(ctx context.Context, metafield Metafield) (*Metafield, error) {
+	if metafield.Namespace == "" || metafield.Key == "" {
+		return nil, ErrMetafieldKey
+	}
+
+	// The filters may not be honoured, look for the metafield in all pages
+	it := newIterator(ctx, 1, "", func(ctx context.Context, page int, sinceID ID) ([]Metafield, error) {
+		options := MetafieldListOptions{Namespace: metafield.Namespace, Key: metafield.Key, Page: page, Limit: 250}
+		return s.ListWithContext(ctx, options)
+	}, func(metafield Metafield) ID { return metafield.ID })
+	for it.Next() {
+		current := it.Value()
+		if current.Namespace == metafield.Namespace && current.Key == metafield.Key {
+			metafield.ID = current.ID
+			return s.UpdateWithContext(ctx, metafield)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	metafield.ID = ""
+	return s.CreateWithContext(ctx, metafield)
+}
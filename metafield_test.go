@@ -0,0 +1,96 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// metafieldsHandler serves one metafield per page, the one of key "target"
+// on page 2 if found is set, ignoring the filters, and records the other
+// requests
+func metafieldsHandler(requests *[]string, found bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			*requests = append(*requests, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{"metafield":{"id":"new"}}`))
+			return
+		}
+		switch page := r.URL.Query().Get("page"); {
+		case page == "1":
+			w.Write([]byte(`{"metafields":[{"id":"m1","namespace":"app","key":"other"}]}`))
+		case page == "2" && found:
+			w.Write([]byte(`{"metafields":[{"id":"m2","namespace":"app","key":"target"}]}`))
+		default:
+			w.Write([]byte(`{"metafields":[]}`))
+		}
+	}
+}
+
+func TestMetafieldUpsert(t *testing.T) {
+	metafield := NewIntegerMetafield("app", "target", 1)
+
+	var requests []string
+	client := newTestClient(t, metafieldsHandler(&requests, true))
+	if _, err := client.Metafield.Upsert(metafield); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "PUT /openapi/metafields/m2" {
+		t.Errorf("requests = %v, want the metafield of the second page updated", requests)
+	}
+
+	requests = nil
+	client = newTestClient(t, metafieldsHandler(&requests, false))
+	if _, err := client.Metafield.Upsert(metafield); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0] != "POST /openapi/metafields" {
+		t.Errorf("requests = %v, want the metafield created", requests)
+	}
+
+	requests = nil
+	for _, invalid := range []Metafield{NewStringMetafield("", "target", "v"), NewStringMetafield("app", "", "v")} {
+		if _, err := client.Metafield.Upsert(invalid); !errors.Is(err, ErrMetafieldKey) {
+			t.Errorf("Upsert(%s.%s) error = %v, want %v", invalid.Namespace, invalid.Key, err, ErrMetafieldKey)
+		}
+	}
+	if len(requests) != 0 {
+		t.Errorf("requests = %v, want none for invalid metafields", requests)
+	}
+}
+
+func TestMetafieldIntegerValue(t *testing.T) {
+	cases := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{`42`, 42, false},
+		{`"42"`, 42, false},
+		{`9007199254740993`, 9007199254740993, false},
+		{`"9007199254740993"`, 9007199254740993, false},
+		{`1.5`, 0, true},
+		{`"many"`, 0, true},
+		{`null`, 0, true},
+	}
+	for _, c := range cases {
+		var metafield Metafield
+		data := fmt.Sprintf(`{"id":"1","namespace":"app","key":"count","value":%s}`, c.value)
+		if err := json.Unmarshal([]byte(data), &metafield); err != nil {
+			t.Fatal(err)
+		}
+		if metafield.ID != "1" || metafield.Key != "count" {
+			t.Errorf("decoded %+v, want the other fields kept", metafield)
+		}
+		got, err := metafield.IntegerValue()
+		if (err != nil) != c.wantErr || got != c.want {
+			t.Errorf("IntegerValue of %s = %d, %v, want %d, error %v", c.value, got, err, c.want, c.wantErr)
+		}
+	}
+
+	if got, err := NewIntegerMetafield("app", "count", 7).IntegerValue(); err != nil || got != 7 {
+		t.Errorf("IntegerValue of NewIntegerMetafield = %d, %v, want 7", got, err)
+	}
+}
@@ -0,0 +1,76 @@
+package goshoplazza
+
+import "net/http"
+
+// RoundTripFunc sends a single request and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of requests by Client.Do, e.g. to log, trace,
+// add headers or capture bodies. A middleware calls next to send the
+// request, and may inspect or replace the request before and the response
+// after doing so:
+//
+//	func(next goshoplazza.RoundTripFunc) goshoplazza.RoundTripFunc {
+//		return func(req *http.Request) (*http.Response, error) {
+//			req.Header.Set("X-Request-Id", newRequestID())
+//			return next(req)
+//		}
+//	}
+//
+// Middlewares run for every attempt, so retried requests pass them again.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware optionally adds middlewares to the client, see Client.Use.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(middleware...)
+	}
+}
+
+// Use appends middlewares to the client's chain. The first middleware added
+// is the outermost one, i.e. it sees the request first and the response
+// last.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// RequestHook returns a middleware calling fn before each request is sent.
+// An error returned by fn aborts the request.
+func RequestHook(fn func(req *http.Request) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// ResponseHook returns a middleware calling fn with the outcome of each
+// request, before the response is checked for errors. fn may read the
+// response body as long as it restores it. An error returned by fn replaces
+// the outcome of the request.
+func ResponseHook(fn func(req *http.Request, resp *http.Response, err error) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			if hookErr := fn(req, resp, err); hookErr != nil {
+				if resp != nil && err == nil {
+					resp.Body.Close()
+				}
+				return nil, hookErr
+			}
+			return resp, err
+		}
+	}
+}
+
+// send sends req through the middleware chain
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.Client.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+	return next(req)
+}
@@ -0,0 +1,111 @@
+package goshoplazza
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// tracing returns a middleware recording name before and after sending
+func tracing(trace *[]string, name string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			*trace = append(*trace, name+">")
+			resp, err := next(req)
+			*trace = append(*trace, "<"+name)
+			return resp, err
+		}
+	}
+}
+
+func TestUseOrder(t *testing.T) {
+	var trace []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace = append(trace, "send")
+		w.Write([]byte(`{}`))
+	}), WithMiddleware(tracing(&trace, "a"), tracing(&trace, "b")))
+	client.Use(tracing(&trace, "c"))
+
+	if err := client.Get("shop", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(trace, " "), "a> b> c> send <c <b <a"; got != want {
+		t.Errorf("trace = %s, want %s", got, want)
+	}
+}
+
+func TestRequestHookAborts(t *testing.T) {
+	requests := 0
+	errAbort := errors.New("abort")
+	calls := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}), fastRetries, WithMiddleware(RequestHook(func(req *http.Request) error {
+		calls++
+		return errAbort
+	})))
+
+	if err := client.Get("shop", nil, nil); !errors.Is(err, errAbort) {
+		t.Errorf("error = %v, want %v", err, errAbort)
+	}
+	if requests != 0 || calls != 1 {
+		t.Errorf("%d requests sent after %d hook calls, want none after a single call", requests, calls)
+	}
+}
+
+func TestResponseHookReplacesError(t *testing.T) {
+	status := http.StatusOK
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}), WithMiddleware(ResponseHook(func(req *http.Request, resp *http.Response, err error) error {
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return errors.New("replaced")
+		}
+		return nil
+	})))
+
+	if err := client.Get("shop", nil, nil); err == nil || err.Error() != "replaced" {
+		t.Errorf("error = %v, want the error of the hook", err)
+	}
+
+	// A nil error of the hook keeps the outcome of the request
+	status = http.StatusNotFound
+	var responseErr ResponseError
+	if err := client.Get("shop", nil, nil); !errors.As(err, &responseErr) || responseErr.Status != http.StatusNotFound {
+		t.Errorf("error = %v, want the 404 response error", err)
+	}
+}
+
+func TestHooksRunPerAttempt(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write([]byte(`{}`))
+	}), fastRetries)
+
+	var sent int
+	var statuses []int
+	client.Use(
+		RequestHook(func(req *http.Request) error {
+			sent++
+			return nil
+		}),
+		ResponseHook(func(req *http.Request, resp *http.Response, err error) error {
+			statuses = append(statuses, resp.StatusCode)
+			return nil
+		}),
+	)
+
+	if err := client.Get("shop", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if sent != 3 || len(statuses) != 3 || statuses[0] != 503 || statuses[2] != 200 {
+		t.Errorf("request hook ran %d times, response hook saw %v, want both once per attempt", sent, statuses)
+	}
+}
@@ -0,0 +1,228 @@
+package goshoplazza
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	oauthAuthorizePath = "admin/oauth/authorize"
+	oauthTokenPath     = "admin/oauth/token"
+	oauthRevokePath    = "admin/oauth/revoke"
+)
+
+// ErrInvalidState is returned when the state of an OAuth callback does not
+// match the state the authorization was started with.
+var ErrInvalidState = errors.New("goshoplazza: oauth state mismatch")
+
+// AccessToken is the token returned by the OAuth token endpoint
+type AccessToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"`
+	ExpiresAt    int64  `json:"expires_at,omitempty"`
+}
+
+// Expiry returns the time the access token expires, or the zero time when
+// Shoplazza did not report one. For tokens returned by GetAccessToken and
+// RefreshAccessToken, ExpiresAt is computed from ExpiresIn on receipt when
+// Shoplazza only sends the latter.
+func (t AccessToken) Expiry() time.Time {
+	if t.ExpiresAt > 0 {
+		return time.Unix(t.ExpiresAt, 0)
+	}
+	return time.Time{}
+}
+
+// NewState returns a random nonce to pass as the state of AuthorizeUrl and
+// to compare against the state of the callback.
+func NewState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// AuthorizeUrl returns the url of the page asking the shop owner to install
+// the app. The state is sent back to the redirect url unchanged, see
+// NewState and VerifyCallback.
+func (app App) AuthorizeUrl(shopName string, state string) string {
+	shopUrl, _ := url.Parse(ShopBaseUrl(shopName))
+	shopUrl.Path = "/" + oauthAuthorizePath
+	query := shopUrl.Query()
+	query.Set("client_id", app.ApiKey)
+	query.Set("redirect_uri", app.RedirectUrl)
+	query.Set("response_type", "code")
+	query.Set("scope", app.Scope)
+	query.Set("state", state)
+	shopUrl.RawQuery = query.Encode()
+	return shopUrl.String()
+}
+
+// VerifyAuthorizationURL verifies the hmac of the url Shoplazza redirected
+// the shop owner to after authorizing the app.
+func (app App) VerifyAuthorizationURL(u *url.URL) (bool, error) {
+	q := u.Query()
+	messageMAC := q.Get("hmac")
+	if messageMAC == "" {
+		return false, errors.New("goshoplazza: hmac missing from authorization url")
+	}
+
+	expectedMAC, err := hex.DecodeString(messageMAC)
+	if err != nil {
+		return false, err
+	}
+
+	return hmac.Equal(app.signQuery(q), expectedMAC), nil
+}
+
+// VerifyCallback verifies both the hmac and the state of the OAuth callback
+// url, state being the value passed to AuthorizeUrl.
+func (app App) VerifyCallback(u *url.URL, state string) error {
+	ok, err := app.VerifyAuthorizationURL(u)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("goshoplazza: invalid hmac on authorization url")
+	}
+	if !hmac.Equal([]byte(u.Query().Get("state")), []byte(state)) {
+		return ErrInvalidState
+	}
+	return nil
+}
+
+// signQuery computes the hmac of all query parameters but hmac itself,
+// sorted by key and joined as key=value pairs.
+func (app App) signQuery(q url.Values) []byte {
+	var keys []string
+	for k := range q {
+		if k != "hmac" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, strings.Join(q[k], ",")))
+	}
+
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write([]byte(strings.Join(pairs, "&")))
+	return mac.Sum(nil)
+}
+
+// GetAccessToken exchanges the code of the OAuth callback for an access
+// token. The options configure the client sending the request, e.g.
+// WithHTTPClient.
+func (app App) GetAccessToken(shopName string, code string, opts ...Option) (*AccessToken, error) {
+	return app.GetAccessTokenWithContext(context.Background(), shopName, code, opts...)
+}
+
+// GetAccessTokenWithContext is like GetAccessToken but uses ctx for the
+// request.
+func (app App) GetAccessTokenWithContext(ctx context.Context, shopName string, code string, opts ...Option) (*AccessToken, error) {
+	data := struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		Code         string `json:"code"`
+		GrantType    string `json:"grant_type"`
+		RedirectUri  string `json:"redirect_uri"`
+	}{
+		ClientID:     app.ApiKey,
+		ClientSecret: app.ApiSecret,
+		Code:         code,
+		GrantType:    "authorization_code",
+		RedirectUri:  app.RedirectUrl,
+	}
+	return app.requestToken(ctx, shopName, data, opts)
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token.
+func (app App) RefreshAccessToken(shopName string, refreshToken string, opts ...Option) (*AccessToken, error) {
+	return app.RefreshAccessTokenWithContext(context.Background(), shopName, refreshToken, opts...)
+}
+
+// RefreshAccessTokenWithContext is like RefreshAccessToken but uses ctx for
+// the request.
+func (app App) RefreshAccessTokenWithContext(ctx context.Context, shopName string, refreshToken string, opts ...Option) (*AccessToken, error) {
+	data := struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		RefreshToken string `json:"refresh_token"`
+		GrantType    string `json:"grant_type"`
+		RedirectUri  string `json:"redirect_uri"`
+	}{
+		ClientID:     app.ApiKey,
+		ClientSecret: app.ApiSecret,
+		RefreshToken: refreshToken,
+		GrantType:    "refresh_token",
+		RedirectUri:  app.RedirectUrl,
+	}
+	return app.requestToken(ctx, shopName, data, opts)
+}
+
+// RevokeAccessToken revokes an access token, uninstalling the app from the
+// shop it was issued for.
+func (app App) RevokeAccessToken(shopName string, token string, opts ...Option) error {
+	return app.RevokeAccessTokenWithContext(context.Background(), shopName, token, opts...)
+}
+
+// RevokeAccessTokenWithContext is like RevokeAccessToken but uses ctx for
+// the request.
+func (app App) RevokeAccessTokenWithContext(ctx context.Context, shopName string, token string, opts ...Option) error {
+	data := struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		Token        string `json:"token"`
+	}{
+		ClientID:     app.ApiKey,
+		ClientSecret: app.ApiSecret,
+		Token:        token,
+	}
+	return app.oauthClient(shopName, opts).PostWithContext(ctx, oauthRevokePath, data, nil)
+}
+
+func (app App) requestToken(ctx context.Context, shopName string, data interface{}, opts []Option) (*AccessToken, error) {
+	token := new(AccessToken)
+	err := app.oauthClient(shopName, opts).PostWithContext(ctx, oauthTokenPath, data, token)
+	if err != nil {
+		return nil, err
+	}
+	if token.ExpiresAt == 0 && token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Unix() + token.ExpiresIn
+	}
+	return token, nil
+}
+
+// oauthClient returns a client for the OAuth endpoints of the shop. The app
+// authenticates with its credentials in the request body, so neither an
+// access token nor the private app password is sent.
+func (app App) oauthClient(shopName string, opts []Option) *Client {
+	app.Password = ""
+	return NewClient(app, shopName, "", opts...)
+}
+
+// NewClientFromCode exchanges the code of the OAuth callback for an access
+// token and returns a client authenticated with it. The token is returned as
+// well so it can be stored for later use. The options apply to both the
+// token request and the returned client.
+func (app App) NewClientFromCode(ctx context.Context, shopName string, code string, opts ...Option) (*Client, *AccessToken, error) {
+	token, err := app.GetAccessTokenWithContext(ctx, shopName, code, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app.NewClient(shopName, token.AccessToken, opts...), token, nil
+}
@@ -0,0 +1,120 @@
+package goshoplazza
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// signedCallback returns a callback url for query signed with secret
+func signedCallback(t *testing.T, secret string, query string) *url.URL {
+	t.Helper()
+	u, err := url.Parse("https://example.com/callback?" + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(q.Encode())) // Encode sorts by key
+	q.Set("hmac", hex.EncodeToString(mac.Sum(nil)))
+	u.RawQuery = q.Encode()
+	return u
+}
+
+func TestVerifyAuthorizationURL(t *testing.T) {
+	u := signedCallback(t, testApp.ApiSecret, "code=abc&shop=theshop.myshoplaza.com&state=xyz&timestamp=1337")
+
+	ok, err := testApp.VerifyAuthorizationURL(u)
+	if err != nil || !ok {
+		t.Errorf("VerifyAuthorizationURL(valid) = %v, %v, want true, nil", ok, err)
+	}
+	if err := testApp.VerifyCallback(u, "xyz"); err != nil {
+		t.Errorf("VerifyCallback(valid) = %v, want nil", err)
+	}
+	if err := testApp.VerifyCallback(u, "other"); err != ErrInvalidState {
+		t.Errorf("VerifyCallback(other state) = %v, want %v", err, ErrInvalidState)
+	}
+
+	tampered := *u
+	q := tampered.Query()
+	q.Set("shop", "evil.myshoplaza.com")
+	tampered.RawQuery = q.Encode()
+	if ok, _ := testApp.VerifyAuthorizationURL(&tampered); ok {
+		t.Error("VerifyAuthorizationURL(tampered query) = true, want false")
+	}
+
+	wrongSecret := signedCallback(t, "other secret", "code=abc&shop=theshop.myshoplaza.com")
+	if ok, _ := testApp.VerifyAuthorizationURL(wrongSecret); ok {
+		t.Error("VerifyAuthorizationURL(wrong secret) = true, want false")
+	}
+
+	missing, _ := url.Parse("https://example.com/callback?code=abc")
+	if _, err := testApp.VerifyAuthorizationURL(missing); err == nil {
+		t.Error("VerifyAuthorizationURL(no hmac) returned no error")
+	}
+}
+
+func TestVerifyWebhookMessage(t *testing.T) {
+	body := []byte(`{"id":"1","name":"#1001"}`)
+	mac := hmac.New(sha256.New, []byte(testApp.ApiSecret))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	cases := []struct {
+		name      string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", body, signature, true},
+		{"tampered body", []byte(`{"id":"1","name":"#1002"}`), signature, false},
+		{"tampered signature", body, base64.StdEncoding.EncodeToString([]byte("nope")), false},
+		{"not base64", body, "%%%", false},
+		{"empty signature", body, "", false},
+	}
+	for _, c := range cases {
+		if got := testApp.VerifyWebhookMessage(c.body, c.signature); got != c.want {
+			t.Errorf("VerifyWebhookMessage(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestGetAccessToken(t *testing.T) {
+	var got *http.Request
+	var body map[string]string
+	httpClient := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+
+	before := time.Now()
+	token, err := testApp.GetAccessToken("theshop", "code", WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.URL.Path != "/"+oauthTokenPath {
+		t.Errorf("path = %s, want /%s", got.URL.Path, oauthTokenPath)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "" {
+		t.Errorf("Authorization header = %q, the private app password must not be sent", auth)
+	}
+	if body["code"] != "code" || body["client_id"] != testApp.ApiKey {
+		t.Errorf("body = %v", body)
+	}
+	if token.AccessToken != "token" {
+		t.Errorf("AccessToken = %q, want token", token.AccessToken)
+	}
+
+	expiry := token.Expiry()
+	if expiry.Before(before.Add(3599*time.Second)) || expiry.After(time.Now().Add(3601*time.Second)) {
+		t.Errorf("Expiry() = %v, want about an hour from now", expiry)
+	}
+}
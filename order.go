@@ -1,7 +1,10 @@
 package goshoplazza
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -15,13 +18,30 @@ const ordersResourceName = "orders"
 // See: https://help.shopify.com/api/reference/order
 type OrderService interface {
 	List(interface{}) ([]Order, error)
+	ListWithContext(context.Context, interface{}) ([]Order, error)
 	Count(interface{}) (int, error)
-	Get(string, interface{}) (*Order, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Order, error)
+	GetWithContext(context.Context, ID, interface{}) (*Order, error)
 	Create(Order) (*Order, error)
+	CreateWithContext(context.Context, Order) (*Order, error)
 	Update(Order) (*Order, error)
+	UpdateWithContext(context.Context, Order) (*Order, error)
+	ListAll(context.Context, *OrderListOptions) ([]Order, error)
+	Iter(context.Context, *OrderListOptions) *Iterator[Order]
+	Cancel(ID, OrderCancelOptions) (*Order, error)
+	CancelWithContext(context.Context, ID, OrderCancelOptions) (*Order, error)
+	Close(ID) (*Order, error)
+	CloseWithContext(context.Context, ID) (*Order, error)
+	Open(ID) (*Order, error)
+	OpenWithContext(context.Context, ID) (*Order, error)
+	AddTags(ID, ...string) (*Order, error)
+	AddTagsWithContext(context.Context, ID, ...string) (*Order, error)
+	RemoveTags(ID, ...string) (*Order, error)
+	RemoveTagsWithContext(context.Context, ID, ...string) (*Order, error)
 
 	// MetafieldsService used for Order resource to communicate with Metafields resource
-	// MetafieldsService
+	MetafieldsService
 
 	// FulfillmentsService used for Order resource to communicate with Fulfillments resource
 	FulfillmentsService
@@ -37,7 +57,7 @@ type OrderServiceOp struct {
 type OrderCountOptions struct {
 	Page              int       `url:"page,omitempty"`
 	Limit             int       `url:"limit,omitempty"`
-	SinceID           int64     `url:"since_id,omitempty"`
+	SinceID           ID        `url:"since_id,omitempty"`
 	CreatedAtMin      time.Time `url:"created_at_min,omitempty"`
 	CreatedAtMax      time.Time `url:"created_at_max,omitempty"`
 	UpdatedAtMin      time.Time `url:"updated_at_min,omitempty"`
@@ -54,7 +74,7 @@ type OrderCountOptions struct {
 type OrderListOptions struct {
 	Page              int       `url:"page,omitempty"`
 	Limit             int       `url:"limit,omitempty"`
-	SinceID           int64     `url:"since_id,omitempty"`
+	SinceID           ID        `url:"since_id,omitempty"`
 	Status            string    `url:"status,omitempty"`
 	FinancialStatus   string    `url:"financial_status,omitempty"`
 	FulfillmentStatus string    `url:"fulfillment_status,omitempty"`
@@ -68,18 +88,39 @@ type OrderListOptions struct {
 	Order             string    `url:"order,omitempty"`
 }
 
+// Reasons for cancelling an order
+const (
+	CancelReasonCustomer  = "customer"
+	CancelReasonFraud     = "fraud"
+	CancelReasonInventory = "inventory"
+	CancelReasonDeclined  = "declined"
+	CancelReasonOther     = "other"
+)
+
+// OrderCancelOptions are the options of cancelling an order. Without an
+// Amount the whole order is refunded; set Refund to false to cancel without
+// refunding. Notify emails the cancellation to the customer.
+type OrderCancelOptions struct {
+	Reason   string           `json:"reason,omitempty"`
+	Restock  bool             `json:"restock,omitempty"`
+	Refund   *bool            `json:"refund,omitempty"`
+	Amount   *decimal.Decimal `json:"amount,omitempty"`
+	Currency string           `json:"currency,omitempty"`
+	Notify   bool             `json:"email,omitempty"`
+}
+
 // Order represents a Shopify order
 type Order struct {
-	ID            string     `json:"id,omitempty"`
-	Name          string     `json:"name,omitempty"`
-	Email         string     `json:"email,omitempty"`
-	CreatedAt     *time.Time `json:"created_at,omitempty"`
-	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
-	CancelledAt   *time.Time `json:"cancelled_at,omitempty"`
-	ClosedAt      *time.Time `json:"closed_at,omitempty"`
-	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
-	PaymentMethod string     `json:"payment_method,omitempty"`
-	// Customer              *Customer        `json:"customer,omitempty"`
+	ID                    ID               `json:"id,omitempty"`
+	Name                  string           `json:"name,omitempty"`
+	Email                 string           `json:"email,omitempty"`
+	CreatedAt             *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt             *time.Time       `json:"updated_at,omitempty"`
+	CancelledAt           *time.Time       `json:"cancelled_at,omitempty"`
+	ClosedAt              *time.Time       `json:"closed_at,omitempty"`
+	ProcessedAt           *time.Time       `json:"processed_at,omitempty"`
+	PaymentMethod         string           `json:"payment_method,omitempty"`
+	Customer              *Customer        `json:"customer,omitempty"`
 	BillingAddress        *Address         `json:"billing_address,omitempty"`
 	ShippingAddress       *Address         `json:"shipping_address,omitempty"`
 	Currency              string           `json:"currency,omitempty"`
@@ -91,7 +132,7 @@ type Order struct {
 	TaxesIncluded         bool             `json:"taxes_included,omitempty"`
 	TotalTax              *decimal.Decimal `json:"total_tax,omitempty"`
 	TaxLines              []TaxLine        `json:"tax_lines,omitempty"`
-	TotalWeight           int              `json:"total_weight,omitempty"`
+	TotalWeight           FlexInt          `json:"total_weight,omitempty"`
 	FinancialStatus       string           `json:"financial_status,omitempty"`
 	Fulfillments          []Fulfillment    `json:"fulfillments,omitempty"`
 	FulfillmentStatus     string           `json:"fulfillment_status,omitempty"`
@@ -109,18 +150,18 @@ type Order struct {
 	LineItems             []LineItem       `json:"line_items,omitempty"`
 	ShippingLine          *ShippingLine    `json:"shipping_line,omitempty"`
 	Transactions          []Transaction    `json:"transactions,omitempty"`
-	AppID                 int              `json:"app_id,omitempty"`
+	AppID                 ID               `json:"app_id,omitempty"`
 	CustomerLocale        string           `json:"customer_locale,omitempty"`
 	LandingSite           string           `json:"landing_site,omitempty"`
 	ReferringSite         string           `json:"referring_site,omitempty"`
 	SourceName            string           `json:"source_name,omitempty"`
 	ClientDetails         *ClientDetails   `json:"client_details,omitempty"`
 	Tags                  string           `json:"tags,omitempty"`
-	LocationId            int64            `json:"location_id,omitempty"`
+	LocationId            ID               `json:"location_id,omitempty"`
 	PaymentGatewayNames   []string         `json:"payment_gateway_names,omitempty"`
 	ProcessingMethod      string           `json:"processing_method,omitempty"`
 	Refunds               []Refund         `json:"refunds,omitempty"`
-	UserId                int64            `json:"user_id,omitempty"`
+	UserId                ID               `json:"user_id,omitempty"`
 	OrderStatusUrl        string           `json:"order_status_url,omitempty"`
 	Gateway               string           `json:"gateway,omitempty"`
 	Confirmed             bool             `json:"confirmed,omitempty"`
@@ -129,16 +170,16 @@ type Order struct {
 	Reference             string           `json:"reference,omitempty"`
 	SourceIdentifier      string           `json:"source_identifier,omitempty"`
 	SourceURL             string           `json:"source_url,omitempty"`
-	DeviceID              int64            `json:"device_id,omitempty"`
+	DeviceID              ID               `json:"device_id,omitempty"`
 	Phone                 string           `json:"phone,omitempty"`
 	LandingSiteRef        string           `json:"landing_site_ref,omitempty"`
-	CheckoutID            int64            `json:"checkout_id,omitempty"`
+	CheckoutID            ID               `json:"checkout_id,omitempty"`
 	ContactEmail          string           `json:"contact_email,omitempty"`
-	// Metafields            []Metafield      `json:"metafields,omitempty"`
+	Metafields            []Metafield      `json:"metafields,omitempty"`
 }
 
 type Address struct {
-	ID           int64   `json:"id,omitempty"`
+	ID           ID      `json:"id,omitempty"`
 	Address1     string  `json:"address1,omitempty"`
 	Address2     string  `json:"address2,omitempty"`
 	City         string  `json:"city,omitempty"`
@@ -163,9 +204,9 @@ type DiscountCode struct {
 }
 
 type LineItem struct {
-	ID                         string           `json:"id,omitempty"`
-	ProductID                  string           `json:"product_id,omitempty"`
-	VariantID                  string           `json:"variant_id,omitempty"`
+	ID                         ID               `json:"id,omitempty"`
+	ProductID                  ID               `json:"product_id,omitempty"`
+	VariantID                  ID               `json:"variant_id,omitempty"`
 	Quantity                   int              `json:"quantity,omitempty"`
 	Price                      *decimal.Decimal `json:"price,omitempty"`
 	TotalDiscount              *decimal.Decimal `json:"total_discount,omitempty"`
@@ -175,15 +216,15 @@ type LineItem struct {
 	SKU                        string           `json:"sku,omitempty"`
 	Vendor                     string           `json:"vendor,omitempty"`
 	GiftCard                   bool             `json:"gift_card,omitempty"`
-	Taxable                    bool             `json:"taxable,omitempty"`
+	Taxable                    FlexBool         `json:"taxable,omitempty"`
 	FulfillmentService         string           `json:"fulfillment_service,omitempty"`
-	RequiresShipping           bool             `json:"requires_shipping,omitempty"`
+	RequiresShipping           FlexBool         `json:"requires_shipping,omitempty"`
 	VariantInventoryManagement string           `json:"variant_inventory_management,omitempty"`
 	PreTaxPrice                *decimal.Decimal `json:"pre_tax_price,omitempty"`
 	Properties                 []NoteAttribute  `json:"properties,omitempty"`
 	ProductExists              bool             `json:"product_exists,omitempty"`
 	FulfillableQuantity        int              `json:"fulfillable_quantity,omitempty"`
-	Grams                      int              `json:"grams,omitempty"`
+	Grams                      FlexInt          `json:"grams,omitempty"`
 	FulfillmentStatus          string           `json:"fulfillment_status,omitempty"`
 	TaxLines                   []TaxLine        `json:"tax_lines,omitempty"`
 	OriginLocation             *Address         `json:"origin_location,omitempty"`
@@ -229,24 +270,24 @@ type TaxLine struct {
 }
 
 type Transaction struct {
-	ID             int64            `json:"id,omitempty"`
-	OrderID        int64            `json:"order_id,omitempty"`
-	Amount         *decimal.Decimal `json:"amount,omitempty"`
-	Kind           string           `json:"kind,omitempty"`
-	Gateway        string           `json:"gateway,omitempty"`
-	Status         string           `json:"status,omitempty"`
-	Message        string           `json:"message,omitempty"`
-	CreatedAt      *time.Time       `json:"created_at,omitempty"`
-	Test           bool             `json:"test,omitempty"`
-	Authorization  string           `json:"authorization,omitempty"`
-	Currency       string           `json:"currency,omitempty"`
-	LocationID     *int64           `json:"location_id,omitempty"`
-	UserID         *int64           `json:"user_id,omitempty"`
-	ParentID       *int64           `json:"parent_id,omitempty"`
-	DeviceID       *int64           `json:"device_id,omitempty"`
-	ErrorCode      string           `json:"error_code,omitempty"`
-	SourceName     string           `json:"source_name,omitempty"`
-	PaymentDetails *PaymentDetails  `json:"payment_details,omitempty"`
+	ID             ID                `json:"id,omitempty"`
+	OrderID        ID                `json:"order_id,omitempty"`
+	Amount         *decimal.Decimal  `json:"amount,omitempty"`
+	Kind           TransactionKind   `json:"kind,omitempty"`
+	Gateway        string            `json:"gateway,omitempty"`
+	Status         TransactionStatus `json:"status,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	CreatedAt      *time.Time        `json:"created_at,omitempty"`
+	Test           bool              `json:"test,omitempty"`
+	Authorization  string            `json:"authorization,omitempty"`
+	Currency       string            `json:"currency,omitempty"`
+	LocationID     ID                `json:"location_id,omitempty"`
+	UserID         ID                `json:"user_id,omitempty"`
+	ParentID       ID                `json:"parent_id,omitempty"`
+	DeviceID       ID                `json:"device_id,omitempty"`
+	ErrorCode      string            `json:"error_code,omitempty"`
+	SourceName     string            `json:"source_name,omitempty"`
+	PaymentDetails *PaymentDetails   `json:"payment_details,omitempty"`
 }
 
 type ClientDetails struct {
@@ -259,145 +300,483 @@ type ClientDetails struct {
 }
 
 type Refund struct {
-	Id              int64            `json:"id,omitempty"`
-	OrderId         int64            `json:"order_id,omitempty"`
+	Id              ID               `json:"id,omitempty"`
+	OrderId         ID               `json:"order_id,omitempty"`
 	CreatedAt       *time.Time       `json:"created_at,omitempty"`
+	ProcessedAt     *time.Time       `json:"processed_at,omitempty"`
 	Note            string           `json:"note,omitempty"`
 	Restock         bool             `json:"restock,omitempty"`
-	UserId          int64            `json:"user_id,omitempty"`
+	Notify          bool             `json:"notify,omitempty"`
+	Currency        string           `json:"currency,omitempty"`
+	UserId          ID               `json:"user_id,omitempty"`
+	Shipping        *RefundShipping  `json:"shipping,omitempty"`
 	RefundLineItems []RefundLineItem `json:"refund_line_items,omitempty"`
 	Transactions    []Transaction    `json:"transactions,omitempty"`
 }
 
+// RefundShipping is the shipping part of a refund. Either refund the full
+// shipping or an Amount of it.
+type RefundShipping struct {
+	FullRefund        bool             `json:"full_refund,omitempty"`
+	Amount            *decimal.Decimal `json:"amount,omitempty"`
+	Tax               *decimal.Decimal `json:"tax,omitempty"`
+	MaximumRefundable *decimal.Decimal `json:"maximum_refundable,omitempty"`
+}
+
+// Restock types of a refund line item
+const (
+	RestockTypeNoRestock = "no_restock"
+	RestockTypeCancel    = "cancel"
+	RestockTypeReturn    = "return"
+)
+
 type RefundLineItem struct {
-	Id         int64            `json:"id,omitempty"`
-	Quantity   int              `json:"quantity,omitempty"`
-	LineItemId int64            `json:"line_item_id,omitempty"`
-	LineItem   *LineItem        `json:"line_item,omitempty"`
-	Subtotal   *decimal.Decimal `json:"subtotal,omitempty"`
-	TotalTax   *decimal.Decimal `json:"total_tax,omitempty"`
+	Id          ID               `json:"id,omitempty"`
+	Quantity    int              `json:"quantity,omitempty"`
+	LineItemId  ID               `json:"line_item_id,omitempty"`
+	LineItem    *LineItem        `json:"line_item,omitempty"`
+	RestockType string           `json:"restock_type,omitempty"`
+	LocationId  ID               `json:"location_id,omitempty"`
+	Subtotal    *decimal.Decimal `json:"subtotal,omitempty"`
+	TotalTax    *decimal.Decimal `json:"total_tax,omitempty"`
 }
 
 // List orders
 func (s *OrderServiceOp) List(options interface{}) ([]Order, error) {
-	path := fmt.Sprintf("%s/%s", globalApiPathPrefix, ordersBasePath)
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists orders using ctx for the request
+func (s *OrderServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Order, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, ordersBasePath)
 	resource := new(OrdersResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Orders, err
 }
 
+// ListAll lists the orders of all pages matching options. Use Iter to
+// process large result sets without loading them into memory.
+func (s *OrderServiceOp) ListAll(ctx context.Context, options *OrderListOptions) ([]Order, error) {
+	return collect(s.Iter(ctx, options))
+}
+
+// Iter returns an iterator over the orders of all pages matching options
+func (s *OrderServiceOp) Iter(ctx context.Context, options *OrderListOptions) *Iterator[Order] {
+	opts := OrderListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	fetch := func(ctx context.Context, page int, sinceID ID) ([]Order, error) {
+		pageOpts := opts
+		pageOpts.Page, pageOpts.SinceID = page, sinceID
+		return s.ListWithContext(ctx, &pageOpts)
+	}
+	id := func(order Order) ID { return order.ID }
+	return newIterator(ctx, opts.Page, opts.SinceID, fetch, id)
+}
+
 // Count orders
 func (s *OrderServiceOp) Count(options interface{}) (int, error) {
-	path := fmt.Sprintf("%s/%s/count", globalApiPathPrefix, ordersBasePath)
-	return s.client.Count(path, options)
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts orders using ctx for the request
+func (s *OrderServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, ordersBasePath)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual order
-func (s *OrderServiceOp) Get(orderID string, options interface{}) (*Order, error) {
-	path := fmt.Sprintf("%s/%s/%s", globalApiPathPrefix, ordersBasePath, orderID)
+func (s *OrderServiceOp) Get(orderID ID, options interface{}) (*Order, error) {
+	return s.GetWithContext(context.Background(), orderID, options)
+}
+
+// GetWithContext gets an individual order using ctx for the request
+func (s *OrderServiceOp) GetWithContext(ctx context.Context, orderID ID, options interface{}) (*Order, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, ordersBasePath, orderID.escape())
 	resource := new(OrderResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Order, err
 }
 
 // Create order
 func (s *OrderServiceOp) Create(order Order) (*Order, error) {
-	path := fmt.Sprintf("%s/%s", globalApiPathPrefix, ordersBasePath)
+	return s.CreateWithContext(context.Background(), order)
+}
+
+// CreateWithContext creates an order using ctx for the request
+func (s *OrderServiceOp) CreateWithContext(ctx context.Context, order Order) (*Order, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, ordersBasePath)
 	wrappedData := OrderResource{Order: &order}
 	resource := new(OrderResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Order, err
 }
 
 // Update order
 func (s *OrderServiceOp) Update(order Order) (*Order, error) {
-	path := fmt.Sprintf("%s/%s/%s", globalApiPathPrefix, ordersBasePath, order.ID)
+	return s.UpdateWithContext(context.Background(), order)
+}
+
+// UpdateWithContext updates an order using ctx for the request
+func (s *OrderServiceOp) UpdateWithContext(ctx context.Context, order Order) (*Order, error) {
+	if err := requireIDs(order.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, ordersBasePath, order.ID.escape())
 	wrappedData := OrderResource{Order: &order}
 	resource := new(OrderResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Order, err
+}
+
+// Cancel an order
+func (s *OrderServiceOp) Cancel(orderID ID, options OrderCancelOptions) (*Order, error) {
+	return s.CancelWithContext(context.Background(), orderID, options)
+}
+
+// CancelWithContext cancels an order using ctx for the request
+func (s *OrderServiceOp) CancelWithContext(ctx context.Context, orderID ID, options OrderCancelOptions) (*Order, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/cancel", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, options, resource)
+	return resource.Order, err
+}
+
+// Close an order
+func (s *OrderServiceOp) Close(orderID ID) (*Order, error) {
+	return s.CloseWithContext(context.Background(), orderID)
+}
+
+// CloseWithContext closes an order using ctx for the request
+func (s *OrderServiceOp) CloseWithContext(ctx context.Context, orderID ID) (*Order, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/close", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
+	return resource.Order, err
+}
+
+// Open re-opens a closed order
+func (s *OrderServiceOp) Open(orderID ID) (*Order, error) {
+	return s.OpenWithContext(context.Background(), orderID)
+}
+
+// OpenWithContext re-opens a closed order using ctx for the request
+func (s *OrderServiceOp) OpenWithContext(ctx context.Context, orderID ID) (*Order, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/open", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	resource := new(OrderResource)
+	err := s.client.PostWithContext(ctx, path, nil, resource)
 	return resource.Order, err
 }
 
+// AddTags adds tags to an order, keeping its current tags, and returns the
+// updated order. Nothing but the tags is written, see editTags for how
+// concurrent changes to the tags are handled.
+func (s *OrderServiceOp) AddTags(orderID ID, tags ...string) (*Order, error) {
+	return s.AddTagsWithContext(context.Background(), orderID, tags...)
+}
+
+// AddTagsWithContext adds tags to an order using ctx for the requests
+func (s *OrderServiceOp) AddTagsWithContext(ctx context.Context, orderID ID, tags ...string) (*Order, error) {
+	return s.editTags(ctx, orderID, func(current []string) []string {
+		for _, tag := range tags {
+			tag = strings.TrimSpace(tag)
+			if tag != "" && indexTag(current, tag) < 0 {
+				current = append(current, tag)
+			}
+		}
+		return current
+	})
+}
+
+// RemoveTags removes tags from an order, keeping its other tags, and
+// returns the updated order. Like AddTags, only the tags are written.
+func (s *OrderServiceOp) RemoveTags(orderID ID, tags ...string) (*Order, error) {
+	return s.RemoveTagsWithContext(context.Background(), orderID, tags...)
+}
+
+// RemoveTagsWithContext removes tags from an order using ctx for the requests
+func (s *OrderServiceOp) RemoveTagsWithContext(ctx context.Context, orderID ID, tags ...string) (*Order, error) {
+	return s.editTags(ctx, orderID, func(current []string) []string {
+		for _, tag := range tags {
+			for i := indexTag(current, tag); i >= 0; i = indexTag(current, tag) {
+				current = append(current[:i], current[i+1:]...)
+			}
+		}
+		return current
+	})
+}
+
+// orderTags is the part of an order written by editTags. Unlike Order it
+// sends empty tags, so the last tag can be removed.
+type orderTags struct {
+	ID   ID     `json:"id"`
+	Tags string `json:"tags"`
+}
+
+type orderTagsResource struct {
+	Order orderTags `json:"order"`
+}
+
+// Number of times editTags reads the tags again after they changed
+// concurrently, before giving up with ErrTagsConflict
+const maxTagEditAttempts = 3
+
+// ErrTagsConflict is returned by AddTags and RemoveTags when the tags of the
+// order kept changing while they were being edited.
+var ErrTagsConflict = errors.New("goshoplazza: order tags changed concurrently")
+
+// editTags reads the current tags of an order, applies edit and writes the
+// result back. Right before writing, the tags and updated_at of the order
+// are read again, and the edit is redone on the fresh tags if the order
+// changed meanwhile. The API has no conditional writes, so a change landing
+// between that last read and the write is still overwritten. Only the tags
+// are written, other fields of the order are never clobbered.
+//
+// The full order is returned, also when edit leaves the tags as they are
+// and nothing is written.
+func (s *OrderServiceOp) editTags(ctx context.Context, orderID ID, edit func([]string) []string) (*Order, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	options := struct {
+		Fields string `url:"fields"`
+	}{"id,tags,updated_at"}
+
+	order, err := s.GetWithContext(ctx, orderID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		current := splitTags(order.Tags)
+		tags := strings.Join(edit(append([]string{}, current...)), ", ")
+		if tags == strings.Join(current, ", ") {
+			return s.GetWithContext(ctx, orderID, nil)
+		}
+
+		check, err := s.GetWithContext(ctx, orderID, options)
+		if err != nil {
+			return nil, err
+		}
+		if check.Tags == order.Tags && sameTime(check.UpdatedAt, order.UpdatedAt) {
+			path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, ordersBasePath, orderID.escape())
+			wrappedData := orderTagsResource{Order: orderTags{ID: orderID, Tags: tags}}
+			resource := new(OrderResource)
+			err = s.client.PutWithContext(ctx, path, wrappedData, resource)
+			return resource.Order, err
+		}
+
+		if attempt == maxTagEditAttempts {
+			return nil, ErrTagsConflict
+		}
+		order = check
+	}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// splitTags splits comma separated tags, dropping empty ones
+func splitTags(tags string) []string {
+	var result []string
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// indexTag returns the index of tag in tags, compared case-insensitively as
+// Shoplazza does, or -1.
+func indexTag(tags []string, tag string) int {
+	tag = strings.TrimSpace(tag)
+	for i, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return i
+		}
+	}
+	return -1
+}
+
 // List metafields for an order
-// func (s *OrderServiceOp) ListMetafields(orderID int64, options interface{}) ([]Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.List(options)
-// }
+func (s *OrderServiceOp) ListMetafields(orderID ID, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), orderID, options)
+}
+
+// ListMetafieldsWithContext lists metafields for an order using ctx for the request
+func (s *OrderServiceOp) ListMetafieldsWithContext(ctx context.Context, orderID ID, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.ListWithContext(ctx, options)
+}
 
 // Count metafields for an order
-// func (s *OrderServiceOp) CountMetafields(orderID int64, options interface{}) (int, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.Count(options)
-// }
+func (s *OrderServiceOp) CountMetafields(orderID ID, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), orderID, options)
+}
+
+// CountMetafieldsWithContext counts metafields for an order using ctx for the request
+func (s *OrderServiceOp) CountMetafieldsWithContext(ctx context.Context, orderID ID, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.CountWithContext(ctx, options)
+}
 
 // Get individual metafield for an order
-// func (s *OrderServiceOp) GetMetafield(orderID int64, metafieldID int64, options interface{}) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.Get(metafieldID, options)
-// }
+func (s *OrderServiceOp) GetMetafield(orderID ID, metafieldID ID, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), orderID, metafieldID, options)
+}
+
+// GetMetafieldWithContext gets an individual metafield for an order using ctx for the request
+func (s *OrderServiceOp) GetMetafieldWithContext(ctx context.Context, orderID ID, metafieldID ID, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
+}
 
 // Create a new metafield for an order
-// func (s *OrderServiceOp) CreateMetafield(orderID int64, metafield Metafield) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.Create(metafield)
-// }
-
-// // Update an existing metafield for an order
-// func (s *OrderServiceOp) UpdateMetafield(orderID int64, metafield Metafield) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.Update(metafield)
-// }
-
-// // Delete an existing metafield for an order
-// func (s *OrderServiceOp) DeleteMetafield(orderID int64, metafieldID int64) error {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-// 	return metafieldService.Delete(metafieldID)
-// }
+func (s *OrderServiceOp) CreateMetafield(orderID ID, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), orderID, metafield)
+}
+
+// CreateMetafieldWithContext creates a new metafield for an order using ctx for the request
+func (s *OrderServiceOp) CreateMetafieldWithContext(ctx context.Context, orderID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.CreateWithContext(ctx, metafield)
+}
+
+// Update an existing metafield for an order
+func (s *OrderServiceOp) UpdateMetafield(orderID ID, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), orderID, metafield)
+}
+
+// UpdateMetafieldWithContext updates an existing metafield for an order using ctx for the request
+func (s *OrderServiceOp) UpdateMetafieldWithContext(ctx context.Context, orderID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.UpdateWithContext(ctx, metafield)
+}
+
+// Delete an existing metafield for an order
+func (s *OrderServiceOp) DeleteMetafield(orderID ID, metafieldID ID) error {
+	return s.DeleteMetafieldWithContext(context.Background(), orderID, metafieldID)
+}
+
+// DeleteMetafieldWithContext deletes an existing metafield for an order using ctx for the request
+func (s *OrderServiceOp) DeleteMetafieldWithContext(ctx context.Context, orderID ID, metafieldID ID) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
+}
+
+// Upsert a metafield for an order by namespace and key
+func (s *OrderServiceOp) UpsertMetafield(orderID ID, metafield Metafield) (*Metafield, error) {
+	return s.UpsertMetafieldWithContext(context.Background(), orderID, metafield)
+}
+
+// UpsertMetafieldWithContext upserts a metafield for an order using ctx for the request
+func (s *OrderServiceOp) UpsertMetafieldWithContext(ctx context.Context, orderID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
+	return metafieldService.UpsertWithContext(ctx, metafield)
+}
 
 // List fulfillments for an order
-func (s *OrderServiceOp) ListFulfillments(orderID string, options interface{}) ([]Fulfillment, error) {
+func (s *OrderServiceOp) ListFulfillments(orderID ID, options interface{}) ([]Fulfillment, error) {
+	return s.ListFulfillmentsWithContext(context.Background(), orderID, options)
+}
+
+// ListFulfillmentsWithContext lists fulfillments for an order using ctx for the request
+func (s *OrderServiceOp) ListFulfillmentsWithContext(ctx context.Context, orderID ID, options interface{}) ([]Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.List(options)
+	return fulfillmentService.ListWithContext(ctx, options)
 }
 
 // Count fulfillments for an order
-func (s *OrderServiceOp) CountFulfillments(orderID string, options interface{}) (int, error) {
+func (s *OrderServiceOp) CountFulfillments(orderID ID, options interface{}) (int, error) {
+	return s.CountFulfillmentsWithContext(context.Background(), orderID, options)
+}
+
+// CountFulfillmentsWithContext counts fulfillments for an order using ctx for the request
+func (s *OrderServiceOp) CountFulfillmentsWithContext(ctx context.Context, orderID ID, options interface{}) (int, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Count(options)
+	return fulfillmentService.CountWithContext(ctx, options)
 }
 
 // Get individual fulfillment for an order
-func (s *OrderServiceOp) GetFulfillment(orderID string, fulfillmentID string, options interface{}) (*Fulfillment, error) {
+func (s *OrderServiceOp) GetFulfillment(orderID ID, fulfillmentID ID, options interface{}) (*Fulfillment, error) {
+	return s.GetFulfillmentWithContext(context.Background(), orderID, fulfillmentID, options)
+}
+
+// GetFulfillmentWithContext gets an individual fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) GetFulfillmentWithContext(ctx context.Context, orderID ID, fulfillmentID ID, options interface{}) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Get(fulfillmentID, options)
+	return fulfillmentService.GetWithContext(ctx, fulfillmentID, options)
 }
 
 // Create a new fulfillment for an order
-func (s *OrderServiceOp) CreateFulfillment(orderID string, fulfillment Fulfillment) (*Fulfillment, error) {
+func (s *OrderServiceOp) CreateFulfillment(orderID ID, fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.CreateFulfillmentWithContext(context.Background(), orderID, fulfillment)
+}
+
+// CreateFulfillmentWithContext creates a new fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) CreateFulfillmentWithContext(ctx context.Context, orderID ID, fulfillment Fulfillment) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Create(fulfillment)
+	return fulfillmentService.CreateWithContext(ctx, fulfillment)
 }
 
 // Update an existing fulfillment for an order
-func (s *OrderServiceOp) UpdateFulfillment(orderID string, fulfillment Fulfillment) (*Fulfillment, error) {
+func (s *OrderServiceOp) UpdateFulfillment(orderID ID, fulfillment Fulfillment) (*Fulfillment, error) {
+	return s.UpdateFulfillmentWithContext(context.Background(), orderID, fulfillment)
+}
+
+// UpdateFulfillmentWithContext updates an existing fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) UpdateFulfillmentWithContext(ctx context.Context, orderID ID, fulfillment Fulfillment) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Update(fulfillment)
+	return fulfillmentService.UpdateWithContext(ctx, fulfillment)
 }
 
 // Complete an existing fulfillment for an order
-func (s *OrderServiceOp) CompleteFulfillment(orderID string, fulfillmentID string) (*Fulfillment, error) {
+func (s *OrderServiceOp) CompleteFulfillment(orderID ID, fulfillmentID ID) (*Fulfillment, error) {
+	return s.CompleteFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// CompleteFulfillmentWithContext completes an existing fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) CompleteFulfillmentWithContext(ctx context.Context, orderID ID, fulfillmentID ID) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Complete(fulfillmentID)
+	return fulfillmentService.CompleteWithContext(ctx, fulfillmentID)
 }
 
 // Transition an existing fulfillment for an order
-func (s *OrderServiceOp) TransitionFulfillment(orderID string, fulfillmentID string) (*Fulfillment, error) {
+func (s *OrderServiceOp) TransitionFulfillment(orderID ID, fulfillmentID ID) (*Fulfillment, error) {
+	return s.TransitionFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// TransitionFulfillmentWithContext transitions an existing fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) TransitionFulfillmentWithContext(ctx context.Context, orderID ID, fulfillmentID ID) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Transition(fulfillmentID)
+	return fulfillmentService.TransitionWithContext(ctx, fulfillmentID)
 }
 
 // Cancel an existing fulfillment for an order
-func (s *OrderServiceOp) CancelFulfillment(orderID string, fulfillmentID string) (*Fulfillment, error) {
+func (s *OrderServiceOp) CancelFulfillment(orderID ID, fulfillmentID ID) (*Fulfillment, error) {
+	return s.CancelFulfillmentWithContext(context.Background(), orderID, fulfillmentID)
+}
+
+// CancelFulfillmentWithContext cancels an existing fulfillment for an order using ctx for the request
+func (s *OrderServiceOp) CancelFulfillmentWithContext(ctx context.Context, orderID ID, fulfillmentID ID) (*Fulfillment, error) {
 	fulfillmentService := &FulfillmentServiceOp{client: s.client, resource: ordersResourceName, resourceID: orderID}
-	return fulfillmentService.Cancel(fulfillmentID)
+	return fulfillmentService.CancelWithContext(ctx, fulfillmentID)
 }
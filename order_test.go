@@ -0,0 +1,96 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// tagsServer serves an order whose tags and updated_at change on each GET
+// according to versions, the last version being repeated.
+type tagsServer struct {
+	versions []string
+	gets     int
+	puts     []string
+}
+
+func (s *tagsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPut {
+		var body struct {
+			Order struct {
+				Tags string `json:"tags"`
+			} `json:"order"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		s.puts = append(s.puts, body.Order.Tags)
+		fmt.Fprintf(w, `{"order":{"id":"1","name":"#1001","tags":%q}}`, body.Order.Tags)
+		return
+	}
+
+	version := s.gets
+	if version >= len(s.versions) {
+		version = len(s.versions) - 1
+	}
+	s.gets++
+	if r.URL.Query().Get("fields") == "" {
+		fmt.Fprintf(w, `{"order":{"id":"1","name":"#1001","tags":%q}}`, s.versions[version])
+		return
+	}
+	fmt.Fprintf(w, `{"order":{"id":"1","tags":%q,"updated_at":"2026-01-01T00:00:0%dZ"}}`, s.versions[version], version)
+}
+
+func TestOrderAddTags(t *testing.T) {
+	srv := &tagsServer{versions: []string{"a"}}
+	client := newTestClient(t, srv)
+
+	order, err := client.Order.AddTags("1", "b", "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.puts) != 1 || srv.puts[0] != "a, b" {
+		t.Errorf("wrote tags %q, want a, b", srv.puts)
+	}
+	if order.Name != "#1001" {
+		t.Errorf("AddTags returned %+v, want the full order", order)
+	}
+}
+
+func TestOrderEditTagsNoop(t *testing.T) {
+	srv := &tagsServer{versions: []string{"a, b"}}
+	client := newTestClient(t, srv)
+
+	order, err := client.Order.RemoveTags("1", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.puts) != 0 {
+		t.Errorf("wrote tags %q for a no-op", srv.puts)
+	}
+	if order.Name != "#1001" || order.Tags != "a, b" {
+		t.Errorf("RemoveTags returned %+v, want the full order", order)
+	}
+}
+
+func TestOrderEditTagsConflict(t *testing.T) {
+	// The tags change once between the first read and the write
+	srv := &tagsServer{versions: []string{"a", "a, b"}}
+	client := newTestClient(t, srv)
+
+	if _, err := client.Order.AddTags("1", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.puts) != 1 || srv.puts[0] != "a, b, c" {
+		t.Errorf("wrote tags %q, want the concurrent tag b kept", srv.puts)
+	}
+
+	// The tags keep changing
+	srv = &tagsServer{versions: []string{"a", "a, b", "a, b, c", "a, b, c, d", "a, b, c, d, e"}}
+	client = newTestClient(t, srv)
+	if _, err := client.Order.AddTags("1", "f"); err != ErrTagsConflict {
+		t.Errorf("AddTags error = %v, want %v", err, ErrTagsConflict)
+	}
+	if len(srv.puts) != 0 {
+		t.Errorf("wrote tags %q despite the conflict", srv.puts)
+	}
+}
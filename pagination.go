@@ -0,0 +1,130 @@
+package goshoplazza
+
+import "context"
+
+// Iterator lazily walks all pages of a List endpoint, fetching the next page
+// only once the items of the current one are consumed:
+//
+//	it := client.Order.Iter(ctx, &OrderListOptions{Limit: 250})
+//	for it.Next() {
+//		order := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Pages are requested by page number, or by since_id when the list options
+// passed to Iter set SinceID or BySinceID is called, in which case the ID of
+// the last item of each page is used as the cursor for the next one. The
+// iteration ends with an empty page, or a page not moving the cursor, so a
+// server returning fewer items than the requested limit is read entirely.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page int, sinceID ID) ([]T, error)
+	id    func(T) ID
+
+	bySinceID bool
+	page      int
+	sinceID   ID
+
+	items   []T
+	current T
+	err     error
+	done    bool
+}
+
+// newIterator returns an iterator starting at the given page or since_id
+// cursor. fetch is called with either the page or the since_id set, never
+// both.
+func newIterator[T any](ctx context.Context, page int, sinceID ID,
+	fetch func(ctx context.Context, page int, sinceID ID) ([]T, error), id func(T) ID) *Iterator[T] {
+	if page < 1 {
+		page = 1
+	}
+	return &Iterator[T]{
+		ctx:       ctx,
+		fetch:     fetch,
+		id:        id,
+		bySinceID: sinceID != "",
+		page:      page,
+		sinceID:   sinceID,
+	}
+}
+
+// BySinceID makes the iterator request its pages by since_id, starting with
+// the first page if no SinceID was set. It must be called before Next.
+func (it *Iterator[T]) BySinceID() *Iterator[T] {
+	it.bySinceID = true
+	return it
+}
+
+// Next advances the iterator to the next item, fetching the next page when
+// needed. It returns false when all items were visited, the context was
+// cancelled or a request failed. Err tells these cases apart.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		it.fetchPage()
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+func (it *Iterator[T]) fetchPage() {
+	var items []T
+	var err error
+	if it.bySinceID {
+		items, err = it.fetch(it.ctx, 0, it.sinceID)
+	} else {
+		items, err = it.fetch(it.ctx, it.page, "")
+	}
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	// Servers may return fewer items than the requested limit, only an empty
+	// page tells the end
+	if len(items) == 0 {
+		it.done = true
+	}
+	if len(items) > 0 {
+		if it.bySinceID {
+			next := it.id(items[len(items)-1])
+			if next == "" || next == it.sinceID {
+				it.done = true
+			}
+			it.sinceID = next
+		} else {
+			it.page++
+		}
+	}
+	it.items = items
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// collect reads all remaining items of it
+func collect[T any](it *Iterator[T]) ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
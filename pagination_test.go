@@ -0,0 +1,106 @@
+package goshoplazza
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// fakePages serves ids 1 to n, at most maxLimit per page whatever the
+// requested limit, by page number or after a since_id
+type fakePages struct {
+	n, maxLimit int
+	requests    []string
+}
+
+func (f *fakePages) fetch(ctx context.Context, page int, sinceID ID) ([]ID, error) {
+	start := 0
+	if sinceID != "" {
+		f.requests = append(f.requests, "since_id="+string(sinceID))
+		start, _ = strconv.Atoi(string(sinceID))
+	} else {
+		f.requests = append(f.requests, "page="+strconv.Itoa(page))
+		if page > 0 {
+			start = (page - 1) * f.maxLimit
+		}
+	}
+
+	var ids []ID
+	for i := start + 1; i <= f.n && len(ids) < f.maxLimit; i++ {
+		ids = append(ids, ID(strconv.Itoa(i)))
+	}
+	return ids, nil
+}
+
+func iterIDs(t *testing.T, it *Iterator[ID]) []ID {
+	t.Helper()
+	ids, err := collect(it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestIteratorShortPages(t *testing.T) {
+	// The server caps the pages at 2 items, whatever limit was asked for
+	f := &fakePages{n: 5, maxLimit: 2}
+	ids := iterIDs(t, newIterator(context.Background(), 1, "", f.fetch, func(id ID) ID { return id }))
+	if len(ids) != 5 {
+		t.Errorf("got %v, want all 5 items", ids)
+	}
+	if len(f.requests) != 4 {
+		t.Errorf("requests = %v, want 3 pages and an empty one", f.requests)
+	}
+}
+
+func TestIteratorBySinceID(t *testing.T) {
+	f := &fakePages{n: 5, maxLimit: 2}
+	ids := iterIDs(t, newIterator(context.Background(), 0, "", f.fetch, func(id ID) ID { return id }).BySinceID())
+	if len(ids) != 5 || ids[0] != "1" || ids[4] != "5" {
+		t.Errorf("got %v, want items 1 to 5", ids)
+	}
+	want := []string{"page=0", "since_id=2", "since_id=4", "since_id=5"}
+	if len(f.requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", f.requests, want)
+	}
+	for i := range want {
+		if f.requests[i] != want[i] {
+			t.Errorf("requests = %v, want %v", f.requests, want)
+			break
+		}
+	}
+
+	f = &fakePages{n: 5, maxLimit: 2}
+	ids = iterIDs(t, newIterator(context.Background(), 0, "3", f.fetch, func(id ID) ID { return id }))
+	if len(ids) != 2 || ids[0] != "4" {
+		t.Errorf("got %v after since_id 3, want items 4 and 5", ids)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	failure := errors.New("unavailable")
+	pages := 0
+	it := newIterator(context.Background(), 1, "", func(ctx context.Context, page int, sinceID ID) ([]ID, error) {
+		pages++
+		if pages == 2 {
+			return nil, failure
+		}
+		return []ID{ID(strconv.Itoa(page))}, nil
+	}, func(id ID) ID { return id })
+
+	var ids []ID
+	for it.Next() {
+		ids = append(ids, it.Value())
+	}
+	if it.Err() != failure || len(ids) != 1 {
+		t.Errorf("got %v, %v, want the first page and the error", ids, it.Err())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = newIterator(ctx, 1, "", (&fakePages{n: 5, maxLimit: 2}).fetch, func(id ID) ID { return id })
+	if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Next on a cancelled context, Err() = %v", it.Err())
+	}
+}
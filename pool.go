@@ -0,0 +1,187 @@
+package goshoplazza
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// ClientPool holds the clients of the shops an app is installed on, keyed
+// by the full shop name, see ShopFullName. Clients are created lazily from
+// the access tokens of a TokenStore and share the pool's http.Client and a
+// rate limiter per shop.
+//
+// A client whose requests are rejected with 401 Unauthorized, e.g. because
+// the app was uninstalled or its token revoked, is evicted from the pool.
+// The next call to Client creates a fresh client from the token store.
+type ClientPool struct {
+	// HTTPClient is shared by all clients of the pool, http.DefaultClient
+	// if nil.
+	HTTPClient *http.Client
+
+	// NewLimiter optionally returns the rate limiter of a shop. It is called
+	// once per shop and the limiter is kept when the shop's client is
+	// evicted, so a recreated client does not start with a full bucket.
+	NewLimiter func(shop string) *RateLimiter
+
+	// OnEvict is optionally called with the shop name when a client is
+	// evicted after a 401 response, e.g. to delete the revoked token.
+	OnEvict func(shop string)
+
+	app    App
+	tokens TokenStore
+	opts   []Option
+
+	mu       sync.Mutex
+	clients  map[string]*Client
+	limiters map[string]*RateLimiter
+	lookups  map[string]*clientLookup
+}
+
+// clientLookup is a token lookup in progress, concurrent calls to Client
+// for the same shop wait for it instead of querying the store again.
+type clientLookup struct {
+	done   chan struct{}
+	client *Client
+	err    error
+}
+
+// NewClientPool returns a pool creating clients for app with the tokens of
+// the given store. The options are applied to every client of the pool.
+func NewClientPool(app App, tokens TokenStore, opts ...Option) *ClientPool {
+	return &ClientPool{
+		app:      app,
+		tokens:   tokens,
+		opts:     opts,
+		clients:  map[string]*Client{},
+		limiters: map[string]*RateLimiter{},
+		lookups:  map[string]*clientLookup{},
+	}
+}
+
+// Client returns the client of shop, creating it from the token store if
+// the pool holds none. ErrTokenNotFound is returned for a shop without
+// token. Concurrent calls for the same shop share a single token lookup,
+// and its error. A call returning early because its ctx is done does not
+// fail the lookup of the others.
+func (p *ClientPool) Client(ctx context.Context, shop string) (*Client, error) {
+	shop = ShopFullName(shop)
+
+	p.mu.Lock()
+	if c, ok := p.clients[shop]; ok {
+		p.mu.Unlock()
+		return c, nil
+	}
+	l, ok := p.lookups[shop]
+	if !ok {
+		l = &clientLookup{done: make(chan struct{})}
+		p.lookups[shop] = l
+		// The lookup is shared, so it must not fail because the caller
+		// starting it gave up. It keeps the values of ctx but not its
+		// cancellation, every caller waits for it as long as its own ctx
+		// allows.
+		go p.lookup(context.WithoutCancel(ctx), shop, l)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-l.done:
+		return l.client, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lookup looks the token of shop up and creates its client
+func (p *ClientPool) lookup(ctx context.Context, shop string, l *clientLookup) {
+	// Look the token up without holding the lock, the store may be slow
+	token, err := p.tokens.Token(ctx, shop)
+
+	p.mu.Lock()
+	delete(p.lookups, shop)
+	if err != nil {
+		l.err = err
+	} else if c, ok := p.clients[shop]; ok {
+		// Registered meanwhile
+		l.client = c
+	} else {
+		l.client = p.newClient(shop, token)
+		p.clients[shop] = l.client
+	}
+	p.mu.Unlock()
+	close(l.done)
+}
+
+// Register saves the token of shop, e.g. after the app was installed, and
+// returns a client using it. Any client of shop using a previous token is
+// replaced.
+func (p *ClientPool) Register(ctx context.Context, shop, token string) (*Client, error) {
+	shop = ShopFullName(shop)
+	if err := p.tokens.SaveToken(ctx, shop, token); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c := p.newClient(shop, token)
+	p.clients[shop] = c
+	return c, nil
+}
+
+// Evict removes the client of shop from the pool. Clients returned earlier
+// remain usable.
+func (p *ClientPool) Evict(shop string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, ShopFullName(shop))
+}
+
+// evictRevoked evicts c after its token was rejected, unless it was already
+// replaced.
+func (p *ClientPool) evictRevoked(shop string, c *Client) {
+	p.mu.Lock()
+	evicted := p.clients[shop] == c
+	if evicted {
+		delete(p.clients, shop)
+	}
+	p.mu.Unlock()
+
+	if evicted && p.OnEvict != nil {
+		p.OnEvict(shop)
+	}
+}
+
+// newClient returns a client of shop, p.mu must be held.
+func (p *ClientPool) newClient(shop, token string) *Client {
+	opts := append([]Option{}, p.opts...)
+	opts = append(opts, func(pooled *Client) {
+		if p.HTTPClient != nil {
+			pooled.Client = p.HTTPClient
+		}
+		if limiter := p.limiter(shop); limiter != nil {
+			pooled.limiter = limiter
+		}
+		// pooled is the client returned by NewClient below, only that very
+		// client is evicted, not a replacement registered since
+		pooled.Use(ResponseHook(func(req *http.Request, resp *http.Response, err error) error {
+			if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+				p.evictRevoked(shop, pooled)
+			}
+			return nil
+		}))
+	})
+	return NewClient(p.app, shop, token, opts...)
+}
+
+// limiter returns the shared limiter of shop, p.mu must be held.
+func (p *ClientPool) limiter(shop string) *RateLimiter {
+	if p.NewLimiter == nil {
+		return nil
+	}
+	limiter, ok := p.limiters[shop]
+	if !ok {
+		limiter = p.NewLimiter(shop)
+		p.limiters[shop] = limiter
+	}
+	return limiter
+}
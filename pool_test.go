@@ -0,0 +1,140 @@
+package goshoplazza
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowTokenStore counts its lookups, which take a while to complete unless
+// their context is cancelled
+type slowTokenStore struct {
+	*MemoryTokenStore
+	lookups int32
+}
+
+func (s *slowTokenStore) Token(ctx context.Context, shop string) (string, error) {
+	atomic.AddInt32(&s.lookups, 1)
+	select {
+	case <-time.After(20 * time.Millisecond):
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	return s.MemoryTokenStore.Token(ctx, shop)
+}
+
+func TestClientPoolSharesTokenLookups(t *testing.T) {
+	store := &slowTokenStore{MemoryTokenStore: NewMemoryTokenStore()}
+	store.SaveToken(context.Background(), "theshop.myshoplaza.com", "token")
+	pool := NewClientPool(testApp, store)
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Client(context.Background(), "theshop")
+			if err != nil {
+				t.Error(err)
+			}
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&store.lookups); n != 1 {
+		t.Errorf("%d token lookups, want 1", n)
+	}
+	for _, c := range clients {
+		if c != clients[0] {
+			t.Fatal("concurrent calls returned different clients")
+		}
+	}
+
+	if _, err := pool.Client(context.Background(), "othershop"); err != ErrTokenNotFound {
+		t.Errorf("Client(othershop) error = %v, want %v", err, ErrTokenNotFound)
+	}
+}
+
+func TestClientPoolEvictsRevokedClient(t *testing.T) {
+	httpClient := newTestServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Access-Token") == "revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"count":1}`))
+	}))
+
+	ctx := context.Background()
+	pool := NewClientPool(testApp, NewMemoryTokenStore())
+	pool.HTTPClient = httpClient
+	var evicted []string
+	pool.OnEvict = func(shop string) { evicted = append(evicted, shop) }
+
+	revoked, _ := pool.Register(ctx, "theshop", "revoked")
+	if _, err := revoked.Order.Count(nil); err == nil {
+		t.Fatal("Count with a revoked token returned no error")
+	}
+	if len(evicted) != 1 || evicted[0] != "theshop.myshoplaza.com" {
+		t.Errorf("evicted %v, want theshop.myshoplaza.com", evicted)
+	}
+
+	// A 401 of a replaced client leaves its replacement in the pool
+	stale, _ := pool.Register(ctx, "theshop", "revoked")
+	fresh, _ := pool.Register(ctx, "theshop", "token")
+	stale.Order.Count(nil)
+	if c, _ := pool.Client(ctx, "theshop"); c != fresh {
+		t.Error("a 401 of a replaced client evicted its replacement")
+	}
+	if len(evicted) != 1 {
+		t.Errorf("evicted %v, want a single eviction", evicted)
+	}
+}
+
+func TestClientPoolLookupOutlivesLeader(t *testing.T) {
+	store := &slowTokenStore{MemoryTokenStore: NewMemoryTokenStore()}
+	store.SaveToken(context.Background(), "theshop.myshoplaza.com", "token")
+	pool := NewClientPool(testApp, store)
+
+	// The leader starting the lookup gives up before it completes
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error)
+	go func() {
+		_, err := pool.Client(ctx, "theshop")
+		leaderErr <- err
+	}()
+	for {
+		pool.mu.Lock()
+		started := len(pool.lookups) > 0
+		pool.mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	waiter := make(chan error)
+	go func() {
+		c, err := pool.Client(context.Background(), "theshop")
+		if err == nil && c == nil {
+			err = ErrTokenNotFound
+		}
+		waiter <- err
+	}()
+	time.Sleep(5 * time.Millisecond) // let the waiter join the lookup
+	cancel()
+
+	if err := <-leaderErr; err != context.Canceled {
+		t.Errorf("leader error = %v, want %v", err, context.Canceled)
+	}
+	if err := <-waiter; err != nil {
+		t.Errorf("waiter error = %v, want the client", err)
+	}
+	if n := atomic.LoadInt32(&store.lookups); n != 1 {
+		t.Errorf("%d token lookups, want 1", n)
+	}
+}
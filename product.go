@@ -1,6 +1,7 @@
 package goshoplazza
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,14 +14,22 @@ const productsResourceName = "products"
 // See: https://help.shopify.com/api/reference/product
 type ProductService interface {
 	List(interface{}) ([]Product, error)
+	ListWithContext(context.Context, interface{}) ([]Product, error)
 	Count(interface{}) (int, error)
-	Get(string, interface{}) (*Product, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Product, error)
+	GetWithContext(context.Context, ID, interface{}) (*Product, error)
 	Create(Product) (*Product, error)
+	CreateWithContext(context.Context, Product) (*Product, error)
 	Update(Product) (*Product, error)
-	Delete(string) error
+	UpdateWithContext(context.Context, Product) (*Product, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	ListAll(context.Context, *ListOptions) ([]Product, error)
+	Iter(context.Context, *ListOptions) *Iterator[Product]
 
 	// MetafieldsService used for Product resource to communicate with Metafields resource
-	// MetafieldsService
+	MetafieldsService
 }
 
 // ProductServiceOp handles communication with the product related methods of
@@ -31,18 +40,18 @@ type ProductServiceOp struct {
 
 // Product represents a Shopify product
 type Product struct {
-	ID                    string          `json:"id,omitempty"`
+	ID                    ID              `json:"id,omitempty"`
 	Title                 string          `json:"title,omitempty"`
 	Brief                 string          `json:"brief,omitempty"`
 	Description           string          `json:"description,omitempty"`
 	Vendor                string          `json:"vendor,omitempty"`
 	VendorURL             string          `json:"vendor_url,omitempty"`
 	HasOnlyDefaultVariant bool            `json:"has_only_default_variant"`
-	RequiresShipping      bool            `json:"requires_shipping"`
-	Taxable               bool            `json:"taxable"`
+	RequiresShipping      FlexBool        `json:"requires_shipping"`
+	Taxable               FlexBool        `json:"taxable"`
 	InventoryTracking     bool            `json:"inventory_tracking"`
 	InventoryPolicy       string          `json:"inventory_policy"`
-	InventoryQuantity     int64           `json:"inventory_quantity"`
+	InventoryQuantity     FlexInt64       `json:"inventory_quantity"`
 	Handle                string          `json:"handle,omitempty"`
 	Tags                  string          `json:"tags,omitempty"`
 	CreatedAt             *time.Time      `json:"created_at,omitempty"`
@@ -62,8 +71,8 @@ type Product struct {
 
 // The options provided by Shopify
 type ProductOption struct {
-	ID        string   `json:"id,omitempty"`
-	ProductID string   `json:"product_id,omitempty"`
+	ID        ID       `json:"id,omitempty"`
+	ProductID ID       `json:"product_id,omitempty"`
 	Name      string   `json:"name,omitempty"`
 	Position  int      `json:"position,omitempty"`
 	Values    []string `json:"values,omitempty"`
@@ -81,81 +90,182 @@ type ProductsResource struct {
 
 // List products
 func (s *ProductServiceOp) List(options interface{}) ([]Product, error) {
-	path := fmt.Sprintf("%s/%s", globalApiPathPrefix, productsBasePath)
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists products using ctx for the request
+func (s *ProductServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Product, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, productsBasePath)
 	resource := new(ProductsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Products, err
 }
 
+// ListAll lists the products of all pages matching options. Use Iter to
+// process large result sets without loading them into memory.
+func (s *ProductServiceOp) ListAll(ctx context.Context, options *ListOptions) ([]Product, error) {
+	return collect(s.Iter(ctx, options))
+}
+
+// Iter returns an iterator over the products of all pages matching options
+func (s *ProductServiceOp) Iter(ctx context.Context, options *ListOptions) *Iterator[Product] {
+	opts := ListOptions{}
+	if options != nil {
+		opts = *options
+	}
+	fetch := func(ctx context.Context, page int, sinceID ID) ([]Product, error) {
+		pageOpts := opts
+		pageOpts.Page, pageOpts.SinceID = page, sinceID
+		return s.ListWithContext(ctx, &pageOpts)
+	}
+	id := func(product Product) ID { return product.ID }
+	return newIterator(ctx, opts.Page, opts.SinceID, fetch, id)
+}
+
 // Count products
 func (s *ProductServiceOp) Count(options interface{}) (int, error) {
-	path := fmt.Sprintf("%s/%s/count", globalApiPathPrefix, productsBasePath)
-	return s.client.Count(path, options)
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts products using ctx for the request
+func (s *ProductServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, productsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual product
-func (s *ProductServiceOp) Get(productID string, options interface{}) (*Product, error) {
-	path := fmt.Sprintf("%s/%s/%s.json", globalApiPathPrefix, productsBasePath, productID)
+func (s *ProductServiceOp) Get(productID ID, options interface{}) (*Product, error) {
+	return s.GetWithContext(context.Background(), productID, options)
+}
+
+// GetWithContext gets an individual product using ctx for the request
+func (s *ProductServiceOp) GetWithContext(ctx context.Context, productID ID, options interface{}) (*Product, error) {
+	if err := requireIDs(productID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s.json", s.client.pathPrefix, productsBasePath, productID.escape())
 	resource := new(ProductResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Product, err
 }
 
 // Create a new product
 func (s *ProductServiceOp) Create(product Product) (*Product, error) {
-	path := fmt.Sprintf("%s/%s", globalApiPathPrefix, productsBasePath)
+	return s.CreateWithContext(context.Background(), product)
+}
+
+// CreateWithContext creates a new product using ctx for the request
+func (s *ProductServiceOp) CreateWithContext(ctx context.Context, product Product) (*Product, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, productsBasePath)
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Update an existing product
 func (s *ProductServiceOp) Update(product Product) (*Product, error) {
-	path := fmt.Sprintf("%s/%s/%d", globalApiPathPrefix, productsBasePath, product.ID)
+	return s.UpdateWithContext(context.Background(), product)
+}
+
+// UpdateWithContext updates an existing product using ctx for the request
+func (s *ProductServiceOp) UpdateWithContext(ctx context.Context, product Product) (*Product, error) {
+	if err := requireIDs(product.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, productsBasePath, product.ID.escape())
 	wrappedData := ProductResource{Product: &product}
 	resource := new(ProductResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Product, err
 }
 
 // Delete an existing product
-func (s *ProductServiceOp) Delete(productID string) error {
-	return s.client.Delete(fmt.Sprintf("%s/%s/%s.json", globalApiPathPrefix, productsBasePath, productID))
+func (s *ProductServiceOp) Delete(productID ID) error {
+	return s.DeleteWithContext(context.Background(), productID)
+}
+
+// DeleteWithContext deletes an existing product using ctx for the request
+func (s *ProductServiceOp) DeleteWithContext(ctx context.Context, productID ID) error {
+	if err := requireIDs(productID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s.json", s.client.pathPrefix, productsBasePath, productID.escape()))
 }
 
 // List metafields for a product
-// func (s *ProductServiceOp) ListMetafields(productID int64, options interface{}) ([]Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.List(options)
-// }
-
-// // Count metafields for a product
-// func (s *ProductServiceOp) CountMetafields(productID int64, options interface{}) (int, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.Count(options)
-// }
-
-// // Get individual metafield for a product
-// func (s *ProductServiceOp) GetMetafield(productID int64, metafieldID int64, options interface{}) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.Get(metafieldID, options)
-// }
-
-// // Create a new metafield for a product
-// func (s *ProductServiceOp) CreateMetafield(productID int64, metafield Metafield) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.Create(metafield)
-// }
-
-// // Update an existing metafield for a product
-// func (s *ProductServiceOp) UpdateMetafield(productID int64, metafield Metafield) (*Metafield, error) {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.Update(metafield)
-// }
-
-// // // Delete an existing metafield for a product
-// func (s *ProductServiceOp) DeleteMetafield(productID int64, metafieldID int64) error {
-// 	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
-// 	return metafieldService.Delete(metafieldID)
-// }
+func (s *ProductServiceOp) ListMetafields(productID ID, options interface{}) ([]Metafield, error) {
+	return s.ListMetafieldsWithContext(context.Background(), productID, options)
+}
+
+// ListMetafieldsWithContext lists metafields for a product using ctx for the request
+func (s *ProductServiceOp) ListMetafieldsWithContext(ctx context.Context, productID ID, options interface{}) ([]Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.ListWithContext(ctx, options)
+}
+
+// Count metafields for a product
+func (s *ProductServiceOp) CountMetafields(productID ID, options interface{}) (int, error) {
+	return s.CountMetafieldsWithContext(context.Background(), productID, options)
+}
+
+// CountMetafieldsWithContext counts metafields for a product using ctx for the request
+func (s *ProductServiceOp) CountMetafieldsWithContext(ctx context.Context, productID ID, options interface{}) (int, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.CountWithContext(ctx, options)
+}
+
+// Get individual metafield for a product
+func (s *ProductServiceOp) GetMetafield(productID ID, metafieldID ID, options interface{}) (*Metafield, error) {
+	return s.GetMetafieldWithContext(context.Background(), productID, metafieldID, options)
+}
+
+// GetMetafieldWithContext gets an individual metafield for a product using ctx for the request
+func (s *ProductServiceOp) GetMetafieldWithContext(ctx context.Context, productID ID, metafieldID ID, options interface{}) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.GetWithContext(ctx, metafieldID, options)
+}
+
+// Create a new metafield for a product
+func (s *ProductServiceOp) CreateMetafield(productID ID, metafield Metafield) (*Metafield, error) {
+	return s.CreateMetafieldWithContext(context.Background(), productID, metafield)
+}
+
+// CreateMetafieldWithContext creates a new metafield for a product using ctx for the request
+func (s *ProductServiceOp) CreateMetafieldWithContext(ctx context.Context, productID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.CreateWithContext(ctx, metafield)
+}
+
+// Update an existing metafield for a product
+func (s *ProductServiceOp) UpdateMetafield(productID ID, metafield Metafield) (*Metafield, error) {
+	return s.UpdateMetafieldWithContext(context.Background(), productID, metafield)
+}
+
+// UpdateMetafieldWithContext updates an existing metafield for a product using ctx for the request
+func (s *ProductServiceOp) UpdateMetafieldWithContext(ctx context.Context, productID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.UpdateWithContext(ctx, metafield)
+}
+
+// Delete an existing metafield for a product
+func (s *ProductServiceOp) DeleteMetafield(productID ID, metafieldID ID) error {
+	return s.DeleteMetafieldWithContext(context.Background(), productID, metafieldID)
+}
+
+// DeleteMetafieldWithContext deletes an existing metafield for a product using ctx for the request
+func (s *ProductServiceOp) DeleteMetafieldWithContext(ctx context.Context, productID ID, metafieldID ID) error {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.DeleteWithContext(ctx, metafieldID)
+}
+
+// Upsert a metafield for a product by namespace and key
+func (s *ProductServiceOp) UpsertMetafield(productID ID, metafield Metafield) (*Metafield, error) {
+	return s.UpsertMetafieldWithContext(context.Background(), productID, metafield)
+}
+
+// UpsertMetafieldWithContext upserts a metafield for a product using ctx for the request
+func (s *ProductServiceOp) UpsertMetafieldWithContext(ctx context.Context, productID ID, metafield Metafield) (*Metafield, error) {
+	metafieldService := &MetafieldServiceOp{client: s.client, resource: productsResourceName, resourceID: productID}
+	return metafieldService.UpsertWithContext(ctx, metafield)
+}
@@ -0,0 +1,140 @@
+package goshoplazza
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit headers returned by Shoplazza
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// RateLimiter is a token bucket pacing the requests of one or more clients.
+// Clients pointing at the same shop share that shop's API quota, so they
+// should share a single RateLimiter as well.
+//
+// The bucket adapts to the rate limit headers of every response: the
+// remaining quota reported by Shoplazza caps the available tokens, and an
+// exhausted quota or a Retry-After header pauses all requests until the
+// quota is reset.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second
+// with bursts of up to burst requests. A rate of zero or less does not pace
+// requests, they are then only paused as told by the rate limit headers.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimiter optionally paces all requests of the client with the given
+// limiter. The limiter may be shared between several clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait := l.reserve()
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it returns how long
+// to wait before trying again.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+	}
+	l.last = now
+}
+
+// Update adapts the limiter to the rate limit headers of resp.
+func (l *RateLimiter) Update(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.refill(now)
+
+	if limit, err := strconv.Atoi(resp.Header.Get(rateLimitLimitHeader)); err == nil && limit > 0 {
+		l.burst = float64(limit)
+	}
+
+	var pause time.Duration
+	if remaining, err := strconv.Atoi(resp.Header.Get(rateLimitRemainingHeader)); err == nil {
+		l.tokens = math.Min(l.tokens, float64(remaining))
+		if remaining <= 0 {
+			reset, _ := strconv.ParseFloat(resp.Header.Get(rateLimitResetHeader), 64)
+			pause = time.Duration(reset * float64(time.Second))
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.tokens = 0
+		if retryAfter, err := strconv.ParseFloat(resp.Header.Get(retryAfterHeader), 64); err == nil {
+			pause = time.Duration(retryAfter * float64(time.Second))
+		}
+	}
+
+	if until := now.Add(pause); pause > 0 && until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
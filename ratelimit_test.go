@@ -0,0 +1,87 @@
+package goshoplazza
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(10, 3)
+	for i := 0; i < 3; i++ {
+		if wait := l.reserve(); wait != 0 {
+			t.Fatalf("request %d of the burst waits %v", i+1, wait)
+		}
+	}
+	if wait := l.reserve(); wait <= 0 || wait > 100*time.Millisecond {
+		t.Errorf("request after the burst waits %v, want up to 100ms", wait)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	l := NewRateLimiter(0, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("request %d: %v, a zero rate must not pace requests", i+1, err)
+		}
+	}
+
+	// Still paused by the headers
+	l.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"1"}}})
+	if wait := l.reserve(); wait <= 0 {
+		t.Error("unlimited limiter not paused by Retry-After")
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		header    http.Header
+		wantPause time.Duration
+	}{
+		{"quota left", http.StatusOK, http.Header{"X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {"2"}}, 0},
+		{"quota exhausted", http.StatusOK, http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"2"}}, 2 * time.Second},
+		{"retry after", http.StatusTooManyRequests, http.Header{"Retry-After": {"3"}}, 3 * time.Second},
+	}
+	for _, c := range cases {
+		l := NewRateLimiter(100, 10)
+		l.Update(&http.Response{StatusCode: c.status, Header: c.header})
+		wait := l.reserve()
+		if c.wantPause == 0 && wait != 0 {
+			t.Errorf("%s: waits %v, want no pause", c.name, wait)
+		}
+		if c.wantPause > 0 && (wait > c.wantPause || wait < c.wantPause-100*time.Millisecond) {
+			t.Errorf("%s: waits %v, want about %v", c.name, wait, c.wantPause)
+		}
+	}
+}
+
+func TestRateLimiterSharedByClients(t *testing.T) {
+	var requests int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0.2")
+		}
+		w.Write([]byte(`{"count":1}`))
+	})
+	limiter := NewRateLimiter(100, 10)
+	first := newTestClient(t, handler, WithRateLimiter(limiter))
+	second := newTestClient(t, handler, WithRateLimiter(limiter))
+
+	if _, err := first.Order.Count(nil); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if _, err := second.Order.Count(nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("second client waited %v, want the pause reported to the first one", elapsed)
+	}
+}
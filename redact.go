@@ -0,0 +1,142 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Redacted replaces credentials and customer PII in logs and recorded
+// interactions
+const Redacted = "REDACTED"
+
+// Redactor removes credentials and customer PII from requests and responses
+// before they are logged or recorded. Redacted JSON values keep their type,
+// so redacted bodies still decode into the same structs.
+type Redactor struct {
+	// Headers replaced as a whole
+	Headers []string
+
+	// Query parameters replaced as a whole
+	Params []string
+
+	// JSON fields redacted wherever they appear in a body. Objects and
+	// arrays, e.g. addresses, are redacted as a whole.
+	Fields []string
+}
+
+// DefaultRedactor redacts credentials, contact details and addresses. The
+// customer search query usually holds an email address or phone number, the
+// OAuth requests and responses the app secret, code and tokens.
+var DefaultRedactor = Redactor{
+	Headers: []string{"Access-Token", "Authorization", "Cookie", "Set-Cookie"},
+	Params:  []string{"email", "phone", "query"},
+	Fields: []string{
+		"client_secret", "code", "access_token", "refresh_token",
+		"email", "contact_email", "phone",
+		"first_name", "last_name", "company",
+		"address1", "address2", "zip", "latitude", "longitude",
+		"browser_ip",
+		"billing_address", "shipping_address", "default_address",
+		"customer_address", "addresses",
+	},
+}
+
+// Header returns a copy of header with the redacted headers replaced
+func (r Redactor) Header(header http.Header) http.Header {
+	clean := header.Clone()
+	for _, name := range r.Headers {
+		if clean.Get(name) != "" {
+			clean.Set(name, Redacted)
+		}
+	}
+	return clean
+}
+
+// Query returns rawQuery with the redacted parameters replaced, sorted by
+// key. A query that does not parse is redacted as a whole.
+func (r Redactor) Query(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	for _, param := range r.Params {
+		if _, ok := query[param]; ok {
+			query.Set(param, Redacted)
+		}
+	}
+	return query.Encode()
+}
+
+// Body returns body with the redacted fields and objects replaced. It
+// returns false if body is not JSON.
+func (r Redactor) Body(body []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+
+	fields := make(map[string]bool, len(r.Fields))
+	for _, field := range r.Fields {
+		fields[field] = true
+	}
+
+	clean, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return nil, false
+	}
+	return clean, true
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			if fields[k] {
+				v[k] = redactAll(elem)
+				continue
+			}
+			v[k] = redactValue(elem, fields)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = redactValue(elem, fields)
+		}
+	}
+	return v
+}
+
+// redactAll redacts every value nested in v
+func redactAll(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			v[k] = redactAll(elem)
+		}
+		return v
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = redactAll(elem)
+		}
+		return v
+	}
+	return redactScalar(v)
+}
+
+// redactScalar redacts strings and numbers. Booleans and nulls hold no PII
+// and are kept.
+func redactScalar(v interface{}) interface{} {
+	switch v.(type) {
+	case string:
+		return Redacted
+	case json.Number:
+		return json.Number("0")
+	}
+	return v
+}
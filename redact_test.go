@@ -0,0 +1,58 @@
+package goshoplazza
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRedactorBody(t *testing.T) {
+	body := `{"order":{"id":1234567890123456789,"name":"#1001","email":"jane@example.com",` +
+		`"billing_address":{"name":"Jane Doe","city":"Paris","latitude":48.85,"default":true},` +
+		`"line_items":[{"name":"Shirt","quantity":2}]}}`
+
+	got, ok := DefaultRedactor.Body([]byte(body))
+	if !ok {
+		t.Fatal("Body() = false for a JSON body")
+	}
+	want := `{"order":{"billing_address":{"city":"REDACTED","default":true,"latitude":0,"name":"REDACTED"},` +
+		`"email":"REDACTED","id":1234567890123456789,"line_items":[{"name":"Shirt","quantity":2}],"name":"#1001"}}`
+	if string(got) != want {
+		t.Errorf("Body() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, ok := DefaultRedactor.Body([]byte("name=Jane")); ok {
+		t.Error("Body() = true for a form body")
+	}
+}
+
+func TestRedactorQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"limit=50&email=jane%40example.com", "email=REDACTED&limit=50"},
+		{"query=jane&page=2", "page=2&query=REDACTED"},
+		{"name=%231001", "name=%231001"},
+		{"email=%zz", Redacted},
+	}
+	for _, c := range cases {
+		if got := DefaultRedactor.Query(c.query); got != c.want {
+			t.Errorf("Query(%q) = %q, want %q", c.query, got, c.want)
+		}
+	}
+}
+
+func TestRedactorHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Access-Token", "secret")
+	header.Set("Content-Type", "application/json")
+
+	got := DefaultRedactor.Header(header)
+	if got.Get("Access-Token") != Redacted || got.Get("Content-Type") != "application/json" {
+		t.Errorf("Header() = %v", got)
+	}
+	if header.Get("Access-Token") != "secret" {
+		t.Error("Header() modified the original header")
+	}
+}
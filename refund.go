@@ -0,0 +1,107 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefundService is an interface for interfacing with the refund endpoints
+// of the Shoplazza API.
+type RefundService interface {
+	List(ID, interface{}) ([]Refund, error)
+	ListWithContext(context.Context, ID, interface{}) ([]Refund, error)
+	Get(ID, ID, interface{}) (*Refund, error)
+	GetWithContext(context.Context, ID, ID, interface{}) (*Refund, error)
+	Calculate(ID, Refund) (*Refund, error)
+	CalculateWithContext(context.Context, ID, Refund) (*Refund, error)
+	Create(ID, Refund) (*Refund, error)
+	CreateWithContext(context.Context, ID, Refund) (*Refund, error)
+}
+
+// RefundServiceOp handles communication with the refund related methods of
+// the Shoplazza API.
+type RefundServiceOp struct {
+	client *Client
+}
+
+// RefundResource represents the result from the orders/X/refunds/Y endpoint
+type RefundResource struct {
+	Refund *Refund `json:"refund"`
+}
+
+// RefundsResource represents the result from the orders/X/refunds endpoint
+type RefundsResource struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+// List refunds of an order
+func (s *RefundServiceOp) List(orderID ID, options interface{}) ([]Refund, error) {
+	return s.ListWithContext(context.Background(), orderID, options)
+}
+
+// ListWithContext lists refunds of an order using ctx for the request
+func (s *RefundServiceOp) ListWithContext(ctx context.Context, orderID ID, options interface{}) ([]Refund, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/refunds", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	resource := new(RefundsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Refunds, err
+}
+
+// Get individual refund of an order
+func (s *RefundServiceOp) Get(orderID, refundID ID, options interface{}) (*Refund, error) {
+	return s.GetWithContext(context.Background(), orderID, refundID, options)
+}
+
+// GetWithContext gets an individual refund of an order using ctx for the
+// request
+func (s *RefundServiceOp) GetWithContext(ctx context.Context, orderID, refundID ID, options interface{}) (*Refund, error) {
+	if err := requireIDs(orderID, refundID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/refunds/%s", s.client.pathPrefix, ordersBasePath, orderID.escape(), refundID.escape())
+	resource := new(RefundResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Refund, err
+}
+
+// Calculate previews a refund without creating it. Shoplazza fills in the
+// amounts, taxes and refundable shipping of the refund line items and
+// suggests the transactions to refund them with, which can be passed on to
+// Create.
+func (s *RefundServiceOp) Calculate(orderID ID, refund Refund) (*Refund, error) {
+	return s.CalculateWithContext(context.Background(), orderID, refund)
+}
+
+// CalculateWithContext previews a refund using ctx for the request
+func (s *RefundServiceOp) CalculateWithContext(ctx context.Context, orderID ID, refund Refund) (*Refund, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/refunds/calculate", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
+
+// Create a refund of an order. Line items are restocked according to their
+// RestockType and the money is returned by the refund's Transactions,
+// usually the ones suggested by Calculate.
+func (s *RefundServiceOp) Create(orderID ID, refund Refund) (*Refund, error) {
+	return s.CreateWithContext(context.Background(), orderID, refund)
+}
+
+// CreateWithContext creates a refund of an order using ctx for the request
+func (s *RefundServiceOp) CreateWithContext(ctx context.Context, orderID ID, refund Refund) (*Refund, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/refunds", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	wrappedData := RefundResource{Refund: &refund}
+	resource := new(RefundResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Refund, err
+}
@@ -0,0 +1,82 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// refundToSend is a partial refund of a line item with restock and part of
+// the shipping
+func refundToSend() Refund {
+	shipping := decimal.RequireFromString("5.00")
+	return Refund{
+		Note:    "damaged",
+		Restock: true,
+		Shipping: &RefundShipping{
+			Amount: &shipping,
+		},
+		RefundLineItems: []RefundLineItem{
+			{LineItemId: "l1", Quantity: 1, RestockType: RestockTypeReturn},
+		},
+	}
+}
+
+func TestRefundCalculate(t *testing.T) {
+	var sent sentRequest
+	client := newRecordingClient(t, `{"refund":{"shipping":{"amount":"5.00","maximum_refundable":"10.00"},"transactions":[{"kind":"suggested_refund","amount":"25.00"}]}}`, &sent)
+
+	refund, err := client.Refund.Calculate("1", refundToSend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Method != "POST" || sent.Path != "/openapi/orders/1/refunds/calculate" {
+		t.Errorf("request = %s %s, want POST /openapi/orders/1/refunds/calculate", sent.Method, sent.Path)
+	}
+	checkRefundSent(t, sent)
+
+	if len(refund.Transactions) != 1 || refund.Transactions[0].Kind != TransactionKindSuggestedRefund {
+		t.Errorf("transactions = %+v, want the suggested refund", refund.Transactions)
+	}
+	if refund.Shipping == nil || refund.Shipping.MaximumRefundable.String() != "10" {
+		t.Errorf("shipping = %+v, want a maximum refundable of 10", refund.Shipping)
+	}
+}
+
+func TestRefundCreate(t *testing.T) {
+	var sent sentRequest
+	client := newRecordingClient(t, `{"refund":{"id":"r1","order_id":"1","transactions":[{"id":"t1","kind":"refund","status":"success","amount":"25.00"}]}}`, &sent)
+
+	refund, err := client.Refund.Create("1", refundToSend())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Method != "POST" || sent.Path != "/openapi/orders/1/refunds" {
+		t.Errorf("request = %s %s, want POST /openapi/orders/1/refunds", sent.Method, sent.Path)
+	}
+	checkRefundSent(t, sent)
+
+	if refund.Id != "r1" || len(refund.Transactions) != 1 || refund.Transactions[0].Status != TransactionStatusSuccess {
+		t.Errorf("refund = %+v, want r1 with its successful transaction", refund)
+	}
+}
+
+// checkRefundSent checks that the refund of refundToSend was sent wrapped
+// in a refund object
+func checkRefundSent(t *testing.T, sent sentRequest) {
+	t.Helper()
+	var refund map[string]json.RawMessage
+	if err := json.Unmarshal(sent.Body["refund"], &refund); err != nil || len(sent.Body) != 1 {
+		t.Fatalf("body = %v, want a single refund object", sent.Body)
+	}
+	if got := string(refund["refund_line_items"]); got != `[{"quantity":1,"line_item_id":"l1","restock_type":"return"}]` {
+		t.Errorf("refund_line_items = %s", got)
+	}
+	if got := string(refund["shipping"]); got != `{"amount":"5"}` {
+		t.Errorf("shipping = %s", got)
+	}
+	if string(refund["restock"]) != "true" || string(refund["note"]) != `"damaged"` {
+		t.Errorf("refund = %v, want the note and restock sent", refund)
+	}
+}
@@ -0,0 +1,175 @@
+package goshoplazza
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how Client.Do retries failed requests.
+//
+// Rate limited responses (429) are always retryable since Shoplazza did not
+// process the request, and wait for the Retry-After header when it is set.
+// Server errors (5xx) and transport errors are only retried for idempotent
+// methods (GET, HEAD, OPTIONS, PUT, DELETE) unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one
+	MaxAttempts int
+
+	// Bounds of the jittered exponential backoff between attempts.
+	// Zero values use a default of 500ms and 30s respectively.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Also retry server and transport errors for POST and PATCH requests
+	RetryNonIdempotent bool
+}
+
+// RetryError is returned by Client.Do when a request still failed after
+// being retried. Err holds the error of the last attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e RetryError) Error() string {
+	return fmt.Sprintf("%s (after %d attempts)", e.Err, e.Attempts)
+}
+
+func (e RetryError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetries optionally enables retries with the default backoff, allowing
+// up to retries additional attempts per request.
+func WithRetries(retries int) Option {
+	return WithRetryPolicy(RetryPolicy{MaxAttempts: retries + 1})
+}
+
+// WithRetryPolicy optionally sets the retry policy used by the client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		if policy.MaxAttempts <= 1 {
+			c.retry = nil
+			return
+		}
+		c.retry = &policy
+	}
+}
+
+// do sends req until it succeeds, fails with a non retryable error or the
+// attempts are exhausted.
+func (p *RetryPolicy) do(c *Client, req *http.Request, v interface{}) error {
+	attempt := 0
+	for {
+		attempt++
+		err := c.do(req, v, attempt)
+		if err == nil {
+			return nil
+		}
+
+		wait, retry := p.backoff(req, err, attempt)
+		if retry && req.Body != nil && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				retry = false
+			} else {
+				req.Body = body
+			}
+		}
+		if !retry {
+			if attempt > 1 {
+				return RetryError{Attempts: attempt, Err: err}
+			}
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return RetryError{Attempts: attempt, Err: req.Context().Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff reports whether the failed attempt should be retried, and how long
+// to wait before doing so.
+func (p *RetryPolicy) backoff(req *http.Request, err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	var rateLimitErr RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		return time.Duration(rateLimitErr.RetryAfter) * time.Second, true
+	}
+
+	// Decide from the status of the response, whatever the error it was
+	// turned into
+	status := 0
+	var responseErr ResponseError
+	var decodingErr ResponseDecodingError
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &responseErr):
+		status = responseErr.Status
+	case errors.As(err, &decodingErr):
+		status = decodingErr.Status
+	case errors.As(err, &urlErr):
+	default:
+		return 0, false
+	}
+
+	if status == http.StatusTooManyRequests {
+		return p.jitter(attempt), true
+	}
+	if status != 0 && status < 500 {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+
+	return p.jitter(attempt), true
+}
+
+// jitter returns a randomized exponential backoff for the given attempt
+func (p *RetryPolicy) jitter(attempt int) time.Duration {
+	min, max := p.MinBackoff, p.MaxBackoff
+	if min <= 0 {
+		min = defaultRetryMinBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+
+	d := min
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	// Wait at least half of the backoff, the remainder is random
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package goshoplazza
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+var fastRetries = WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+func TestRetryByStatus(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		status       int
+		contentType  string
+		body         string
+		wantAttempts int
+	}{
+		{"html bad gateway", http.MethodGet, http.StatusBadGateway, "text/html", "<html>Bad Gateway</html>", 2},
+		{"plain text rate limit", http.MethodGet, http.StatusTooManyRequests, "text/plain", "Too Many Requests", 2},
+		{"plain text rate limit post", http.MethodPost, http.StatusTooManyRequests, "text/plain", "Too Many Requests", 2},
+		{"json server error", http.MethodGet, http.StatusServiceUnavailable, "application/json", `{"errors":"Unavailable"}`, 2},
+		{"html server error post", http.MethodPost, http.StatusBadGateway, "text/html", "<html>Bad Gateway</html>", 1},
+		{"html not found", http.MethodGet, http.StatusNotFound, "text/html", "<html>Not Found</html>", 1},
+		{"malformed success", http.MethodGet, http.StatusOK, "application/json", `{"order":`, 1},
+	}
+	for _, c := range cases {
+		attempts := 0
+		client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts > 1 {
+				w.Write([]byte(`{"order":{"id":"1"}}`))
+				return
+			}
+			w.Header().Set("Content-Type", c.contentType)
+			w.WriteHeader(c.status)
+			w.Write([]byte(c.body))
+		}), fastRetries)
+
+		resource := new(OrderResource)
+		err := client.CreateAndDo(c.method, "orders/1", nil, nil, resource)
+		if attempts != c.wantAttempts {
+			t.Errorf("%s: %d attempts, want %d", c.name, attempts, c.wantAttempts)
+		}
+		if c.wantAttempts > 1 && err != nil {
+			t.Errorf("%s: error after retrying = %v", c.name, err)
+		}
+		if c.wantAttempts == 1 && err == nil {
+			t.Errorf("%s: no error without retrying", c.name)
+		}
+	}
+}
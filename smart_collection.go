@@ -0,0 +1,224 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const smartCollectionsBasePath = "smart_collections"
+
+// Columns of a smart collection rule
+const (
+	RuleColumnTitle                 = "title"
+	RuleColumnType                  = "type"
+	RuleColumnVendor                = "vendor"
+	RuleColumnTag                   = "tag"
+	RuleColumnVariantTitle          = "variant_title"
+	RuleColumnVariantPrice          = "variant_price"
+	RuleColumnVariantCompareAtPrice = "variant_compare_at_price"
+	RuleColumnVariantWeight         = "variant_weight"
+	RuleColumnVariantInventory      = "variant_inventory"
+)
+
+// Relations of a smart collection rule
+const (
+	RuleRelationEquals      = "equals"
+	RuleRelationNotEquals   = "not_equals"
+	RuleRelationGreaterThan = "greater_than"
+	RuleRelationLessThan    = "less_than"
+	RuleRelationStartsWith  = "starts_with"
+	RuleRelationEndsWith    = "ends_with"
+	RuleRelationContains    = "contains"
+	RuleRelationNotContains = "not_contains"
+)
+
+// SmartCollectionService is an interface for interfacing with the smart
+// collection endpoints of the Shoplazza API.
+type SmartCollectionService interface {
+	List(interface{}) ([]SmartCollection, error)
+	ListWithContext(context.Context, interface{}) ([]SmartCollection, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*SmartCollection, error)
+	GetWithContext(context.Context, ID, interface{}) (*SmartCollection, error)
+	Create(SmartCollection) (*SmartCollection, error)
+	CreateWithContext(context.Context, SmartCollection) (*SmartCollection, error)
+	Update(SmartCollection) (*SmartCollection, error)
+	UpdateWithContext(context.Context, SmartCollection) (*SmartCollection, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	Reorder(ID, []ID) error
+	ReorderWithContext(context.Context, ID, []ID) error
+	ListProducts(ID, interface{}) ([]Product, error)
+	ListProductsWithContext(context.Context, ID, interface{}) ([]Product, error)
+	IterProducts(context.Context, ID, *ListOptions) *Iterator[Product]
+}
+
+// SmartCollectionServiceOp handles communication with the smart collection
+// related methods of the Shoplazza API.
+type SmartCollectionServiceOp struct {
+	client *Client
+}
+
+// Rule selects the products of a smart collection, e.g. all products whose
+// vendor equals "Acme".
+type Rule struct {
+	Column    string `json:"column"`
+	Relation  string `json:"relation"`
+	Condition string `json:"condition"`
+}
+
+// SmartCollection represents a Shoplazza collection whose products are
+// selected by rules. With Disjunctive a product matching any rule is
+// included, otherwise it has to match all rules.
+type SmartCollection struct {
+	ID             ID               `json:"id,omitempty"`
+	Handle         string           `json:"handle,omitempty"`
+	Title          string           `json:"title,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	SortOrder      string           `json:"sort_order,omitempty"`
+	TemplateSuffix string           `json:"template_suffix,omitempty"`
+	Image          *CollectionImage `json:"image,omitempty"`
+	Published      bool             `json:"published,omitempty"`
+	PublishedAt    *time.Time       `json:"published_at,omitempty"`
+	PublishedScope string           `json:"published_scope,omitempty"`
+	Rules          []Rule           `json:"rules,omitempty"`
+	Disjunctive    bool             `json:"disjunctive"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+// SmartCollectionResource represents the result from the smart_collections/X endpoint
+type SmartCollectionResource struct {
+	SmartCollection *SmartCollection `json:"smart_collection"`
+}
+
+// SmartCollectionsResource represents the result from the smart_collections endpoint
+type SmartCollectionsResource struct {
+	SmartCollections []SmartCollection `json:"smart_collections"`
+}
+
+type smartCollectionOrderOptions struct {
+	Products  []ID   `url:"products[],omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+// List smart collections
+func (s *SmartCollectionServiceOp) List(options interface{}) ([]SmartCollection, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists smart collections using ctx for the request
+func (s *SmartCollectionServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]SmartCollection, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, smartCollectionsBasePath)
+	resource := new(SmartCollectionsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.SmartCollections, err
+}
+
+// Count smart collections
+func (s *SmartCollectionServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts smart collections using ctx for the request
+func (s *SmartCollectionServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, smartCollectionsBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual smart collection
+func (s *SmartCollectionServiceOp) Get(collectionID ID, options interface{}) (*SmartCollection, error) {
+	return s.GetWithContext(context.Background(), collectionID, options)
+}
+
+// GetWithContext gets an individual smart collection using ctx for the request
+func (s *SmartCollectionServiceOp) GetWithContext(ctx context.Context, collectionID ID, options interface{}) (*SmartCollection, error) {
+	if err := requireIDs(collectionID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, smartCollectionsBasePath, collectionID.escape())
+	resource := new(SmartCollectionResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.SmartCollection, err
+}
+
+// Create a new smart collection
+func (s *SmartCollectionServiceOp) Create(collection SmartCollection) (*SmartCollection, error) {
+	return s.CreateWithContext(context.Background(), collection)
+}
+
+// CreateWithContext creates a new smart collection using ctx for the request
+func (s *SmartCollectionServiceOp) CreateWithContext(ctx context.Context, collection SmartCollection) (*SmartCollection, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, smartCollectionsBasePath)
+	wrappedData := SmartCollectionResource{SmartCollection: &collection}
+	resource := new(SmartCollectionResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.SmartCollection, err
+}
+
+// Update an existing smart collection. The rules of the collection are
+// replaced by the given ones.
+func (s *SmartCollectionServiceOp) Update(collection SmartCollection) (*SmartCollection, error) {
+	return s.UpdateWithContext(context.Background(), collection)
+}
+
+// UpdateWithContext updates an existing smart collection using ctx for the request
+func (s *SmartCollectionServiceOp) UpdateWithContext(ctx context.Context, collection SmartCollection) (*SmartCollection, error) {
+	if err := requireIDs(collection.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, smartCollectionsBasePath, collection.ID.escape())
+	wrappedData := SmartCollectionResource{SmartCollection: &collection}
+	resource := new(SmartCollectionResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.SmartCollection, err
+}
+
+// Delete an existing smart collection
+func (s *SmartCollectionServiceOp) Delete(collectionID ID) error {
+	return s.DeleteWithContext(context.Background(), collectionID)
+}
+
+// DeleteWithContext deletes an existing smart collection using ctx for the request
+func (s *SmartCollectionServiceOp) DeleteWithContext(ctx context.Context, collectionID ID) error {
+	if err := requireIDs(collectionID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, smartCollectionsBasePath, collectionID.escape()))
+}
+
+// Reorder sorts the products of a smart collection manually, in the order
+// of productIDs.
+func (s *SmartCollectionServiceOp) Reorder(collectionID ID, productIDs []ID) error {
+	return s.ReorderWithContext(context.Background(), collectionID, productIDs)
+}
+
+// ReorderWithContext reorders the products of a smart collection using ctx
+// for the request
+func (s *SmartCollectionServiceOp) ReorderWithContext(ctx context.Context, collectionID ID, productIDs []ID) error {
+	if err := requireIDs(collectionID); err != nil {
+		return err
+	}
+	path := fmt.Sprintf("%s/%s/%s/order", s.client.pathPrefix, smartCollectionsBasePath, collectionID.escape())
+	options := smartCollectionOrderOptions{Products: productIDs, SortOrder: CollectionSortManual}
+	return s.client.CreateAndDoWithContext(ctx, "PUT", path, nil, options, nil)
+}
+
+// ListProducts lists the products of a smart collection
+func (s *SmartCollectionServiceOp) ListProducts(collectionID ID, options interface{}) ([]Product, error) {
+	return s.ListProductsWithContext(context.Background(), collectionID, options)
+}
+
+// ListProductsWithContext lists the products of a smart collection using
+// ctx for the request
+func (s *SmartCollectionServiceOp) ListProductsWithContext(ctx context.Context, collectionID ID, options interface{}) ([]Product, error) {
+	return s.client.listCollectionProducts(ctx, collectionID, options)
+}
+
+// IterProducts returns an iterator over the products of all pages of a
+// smart collection
+func (s *SmartCollectionServiceOp) IterProducts(ctx context.Context, collectionID ID, options *ListOptions) *Iterator[Product] {
+	return s.client.iterCollectionProducts(ctx, collectionID, options)
+}
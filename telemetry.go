@@ -0,0 +1,236 @@
+package goshoplazza
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/ztp130213/goshoplazza"
+
+// telemetry holds the OpenTelemetry tracer and instruments of a client
+type telemetry struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	rateLimited metric.Int64Counter
+	duration    metric.Float64Histogram
+}
+
+// WithTracerProvider optionally traces every API call of the client, i.e.
+// each call to CreateAndDo, with a span from provider. Spans are named after
+// the resource and operation, e.g. orders.list or orders.fulfillments.create.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.telemetry.tracer = provider.Tracer(instrumentationName)
+	}
+}
+
+// WithMeterProvider optionally records the request count, latency and rate
+// limited responses of the client with instruments from provider. Rate
+// limited responses are counted per attempt, including retried ones.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *Client) {
+		c.telemetry.setMeter(provider.Meter(instrumentationName))
+	}
+}
+
+// newTelemetry returns telemetry doing nothing until a provider is set
+func newTelemetry() telemetry {
+	t := telemetry{tracer: tracenoop.NewTracerProvider().Tracer(instrumentationName)}
+	t.setMeter(metricnoop.NewMeterProvider().Meter(instrumentationName))
+	return t
+}
+
+func (t *telemetry) setMeter(meter metric.Meter) {
+	noop := metricnoop.Meter{}
+
+	var err error
+	t.requests, err = meter.Int64Counter("shoplazza.client.requests",
+		metric.WithDescription("Number of Shoplazza API calls"))
+	if err != nil {
+		t.requests, _ = noop.Int64Counter("")
+	}
+	t.rateLimited, err = meter.Int64Counter("shoplazza.client.rate_limited",
+		metric.WithDescription("Number of Shoplazza API requests rejected by rate limiting"))
+	if err != nil {
+		t.rateLimited, _ = noop.Int64Counter("")
+	}
+	t.duration, err = meter.Float64Histogram("shoplazza.client.duration",
+		metric.WithDescription("Duration of Shoplazza API calls, including retries"),
+		metric.WithUnit("s"))
+	if err != nil {
+		t.duration, _ = noop.Float64Histogram("")
+	}
+}
+
+// callInfo collects the outcome of the attempts of an API call, it is
+// passed to Do through the request context.
+type callInfo struct {
+	name     string
+	status   int
+	attempts int
+}
+
+type callInfoKey struct{}
+
+// recordAttempt records the status of an attempt of req in the call info of
+// its context, and counts it if it was rate limited.
+func (c *Client) recordAttempt(req *http.Request, status int) {
+	ctx := req.Context()
+	info, ok := ctx.Value(callInfoKey{}).(*callInfo)
+	if ok {
+		info.attempts++
+		info.status = status
+	}
+	if status != http.StatusTooManyRequests {
+		return
+	}
+
+	name := c.resourceName(req.Method, req.URL.Path)
+	if ok {
+		name = info.name
+	}
+	c.telemetry.rateLimited.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("shoplazza.resource", name),
+		attribute.String("http.request.method", req.Method),
+	))
+}
+
+// startCall starts the span of an API call, the returned function ends it.
+func (c *Client) startCall(ctx context.Context, method, path string) (context.Context, func(error)) {
+	name := c.resourceName(method, path)
+	start := time.Now()
+
+	info := &callInfo{name: name}
+	ctx = context.WithValue(ctx, callInfoKey{}, info)
+	ctx, span := c.telemetry.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("shoplazza.resource", name),
+			attribute.String("http.request.method", method),
+			attribute.String("server.address", c.baseURL.Host),
+		))
+
+	return ctx, func(err error) {
+		// The host is left out of the metric attributes, it differs for
+		// every shop
+		attrs := []attribute.KeyValue{
+			attribute.String("shoplazza.resource", name),
+			attribute.String("http.request.method", method),
+		}
+		if info.status != 0 {
+			attrs = append(attrs, attribute.Int("http.response.status_code", info.status))
+		}
+		if err != nil {
+			attrs = append(attrs, attribute.String("error.type", errorType(err)))
+		}
+
+		span.SetAttributes(attrs...)
+		span.SetAttributes(attribute.Int("shoplazza.attempts", info.attempts))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		set := metric.WithAttributes(attrs...)
+		c.telemetry.requests.Add(ctx, 1, set)
+		c.telemetry.duration.Record(ctx, time.Since(start).Seconds(), set)
+	}
+}
+
+// resourceName names the API call for the given method and path, e.g.
+// orders.list, orders.get or orders.fulfillments.complete. ID segments are
+// left out.
+func (c *Client) resourceName(method, path string) string {
+	path = strings.TrimSuffix(strings.Split(path, "?")[0], ".json")
+	path = strings.TrimPrefix(strings.Trim(path, "/"), c.pathPrefix+"/")
+
+	var names []string
+	lastWasID := false
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		lastWasID = isIDSegment(segment)
+		if !lastWasID {
+			names = append(names, segment)
+		}
+	}
+	if len(names) == 0 {
+		return strings.ToLower(method)
+	}
+
+	// An action on a resource or collection, e.g. count or complete
+	if !lastWasID && isAction(names[len(names)-1]) {
+		return strings.Join(names, ".")
+	}
+
+	var op string
+	switch method {
+	case http.MethodGet:
+		op = "list"
+		if lastWasID {
+			op = "get"
+		}
+	case http.MethodPost:
+		op = "create"
+	case http.MethodPut, http.MethodPatch:
+		op = "update"
+	case http.MethodDelete:
+		op = "delete"
+	default:
+		op = strings.ToLower(method)
+	}
+	return strings.Join(names, ".") + "." + op
+}
+
+// isAction reports whether a path segment is an action rather than a
+// resource, e.g. orders/count or fulfillments/{id}/complete.
+func isAction(segment string) bool {
+	switch segment {
+	case "count", "search", "default", "complete", "open", "cancel", "close", "send_invoice", "calculate", "order":
+		return true
+	}
+	return false
+}
+
+// Shoplazza IDs are UUIDs, some endpoints use plain numbers
+var idSegmentRegex = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// isIDSegment reports whether a path segment is a resource ID
+func isIDSegment(segment string) bool {
+	return idSegmentRegex.MatchString(segment)
+}
+
+// errorType names the kind of err for the error.type attribute
+func errorType(err error) string {
+	var rateLimitErr RateLimitError
+	var responseErr ResponseError
+	var decodingErr ResponseDecodingError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &rateLimitErr):
+		return "rate_limited"
+	case errors.As(err, &decodingErr):
+		return "decoding"
+	case errors.As(err, &responseErr):
+		return strconv.Itoa(responseErr.Status)
+	}
+	return fmt.Sprintf("%T", err)
+}
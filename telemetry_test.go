@@ -0,0 +1,101 @@
+package goshoplazza
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+)
+
+// recordingCounter keeps the attributes of every increment
+type recordingCounter struct {
+	metricnoop.Int64Counter
+	adds []attribute.Set
+}
+
+func (c *recordingCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.adds = append(c.adds, metric.NewAddConfig(options).Attributes())
+}
+
+func TestTelemetryRateLimitedPerAttempt(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"order":{"id":"1"}}`))
+	}))
+	WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})(client)
+	requests, rateLimited := new(recordingCounter), new(recordingCounter)
+	client.telemetry.requests, client.telemetry.rateLimited = requests, rateLimited
+
+	if _, err := client.Order.Get("1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rateLimited.adds) != 2 {
+		t.Fatalf("rate limited count = %d, want one per rate limited attempt", len(rateLimited.adds))
+	}
+	if v, _ := rateLimited.adds[0].Value("shoplazza.resource"); v.AsString() != "orders.get" {
+		t.Errorf("shoplazza.resource = %q, want orders.get", v.AsString())
+	}
+	if len(requests.adds) != 1 {
+		t.Fatalf("requests count = %d, want 1", len(requests.adds))
+	}
+	if requests.adds[0].HasValue("server.address") {
+		t.Error("requests counted with server.address, its cardinality grows with the shops")
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	client := NewClient(testApp, "theshop", "token")
+	uuid := "1b4e28ba-2fa1-11d2-883f-0060b8e9a7d2"
+
+	cases := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "openapi/orders", "orders.list"},
+		{http.MethodGet, "openapi/orders/count", "orders.count"},
+		{http.MethodGet, "openapi/orders/" + uuid, "orders.get"},
+		{http.MethodGet, "openapi/products/12345.json", "products.get"},
+		{http.MethodPost, "openapi/orders/" + uuid + "/fulfillments/" + uuid + "/complete", "orders.fulfillments.complete"},
+		{http.MethodPut, "openapi/products/" + uuid + "/variants/7.json", "products.variants.update"},
+		{http.MethodGet, "openapi/products/" + uuid + "/metafields", "products.metafields.list"},
+		{http.MethodGet, "openapi/v2shops", "v2shops.list"},
+	}
+	for _, c := range cases {
+		if got := client.resourceName(c.method, c.path); got != c.want {
+			t.Errorf("resourceName(%s, %s) = %s, want %s", c.method, c.path, got, c.want)
+		}
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{context.Canceled, "canceled"},
+		{RetryError{Attempts: 2, Err: context.Canceled}, "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), "timeout"},
+		{RateLimitError{ResponseError: ResponseError{Status: 429}}, "rate_limited"},
+		{ResponseError{Status: 404}, "404"},
+		{errors.New("boom"), "*errors.errorString"},
+	}
+	for _, c := range cases {
+		if got := errorType(c.err); got != c.want {
+			t.Errorf("errorType(%v) = %s, want %s", c.err, got, c.want)
+		}
+	}
+}
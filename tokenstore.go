@@ -0,0 +1,165 @@
+package goshoplazza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by a TokenStore that has no access token for
+// the requested shop.
+var ErrTokenNotFound = errors.New("goshoplazza: no access token for shop")
+
+// TokenStore stores the access tokens of the shops an app is installed on,
+// keyed by the full shop name, see ShopFullName.
+type TokenStore interface {
+	// Token returns the access token of shop, or ErrTokenNotFound
+	Token(ctx context.Context, shop string) (string, error)
+	// SaveToken stores the access token of shop, replacing any previous one
+	SaveToken(ctx context.Context, shop, token string) error
+	// DeleteToken removes the access token of shop, if any
+	DeleteToken(ctx context.Context, shop string) error
+}
+
+// MemoryTokenStore is a TokenStore keeping the tokens in memory
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]string{}}
+}
+
+// Token returns the access token of shop
+func (s *MemoryTokenStore) Token(ctx context.Context, shop string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	token, ok := s.tokens[ShopFullName(shop)]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// SaveToken stores the access token of shop
+func (s *MemoryTokenStore) SaveToken(ctx context.Context, shop, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[ShopFullName(shop)] = token
+	return nil
+}
+
+// DeleteToken removes the access token of shop
+func (s *MemoryTokenStore) DeleteToken(ctx context.Context, shop string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, ShopFullName(shop))
+	return nil
+}
+
+// FileTokenStore is a TokenStore keeping the tokens in a JSON file mapping
+// shop names to tokens. The file is read on every lookup so tokens saved by
+// other processes are picked up, and replaced atomically on every change.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a FileTokenStore backed by the file at path. The
+// file is created on the first save.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Token returns the access token of shop
+func (s *FileTokenStore) Token(ctx context.Context, shop string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	token, ok := tokens[ShopFullName(shop)]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// SaveToken stores the access token of shop
+func (s *FileTokenStore) SaveToken(ctx context.Context, shop, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	tokens[ShopFullName(shop)] = token
+	return s.save(tokens)
+}
+
+// DeleteToken removes the access token of shop
+func (s *FileTokenStore) DeleteToken(ctx context.Context, shop string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[ShopFullName(shop)]; !ok {
+		return nil
+	}
+	delete(tokens, ShopFullName(shop))
+	return s.save(tokens)
+}
+
+func (s *FileTokenStore) load() (map[string]string, error) {
+	tokens := map[string]string{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// save writes tokens to a temporary file readable by the owner only and
+// renames it over the store's file.
+func (s *FileTokenStore) save(tokens map[string]string) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
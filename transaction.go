@@ -0,0 +1,134 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+)
+
+// TransactionKind is the kind of a transaction
+type TransactionKind string
+
+// Kinds of a transaction
+const (
+	// TransactionKindAuthorization reserves money to be captured later
+	TransactionKindAuthorization TransactionKind = "authorization"
+	// TransactionKindCapture transfers money previously authorized
+	TransactionKindCapture TransactionKind = "capture"
+	// TransactionKindSale authorizes and captures money at once, e.g. a
+	// manual payment of an offline order
+	TransactionKindSale TransactionKind = "sale"
+	// TransactionKindVoid cancels a pending authorization
+	TransactionKindVoid TransactionKind = "void"
+	// TransactionKindRefund returns money to the customer
+	TransactionKindRefund TransactionKind = "refund"
+	// TransactionKindSuggestedRefund is a refund suggested by
+	// RefundService.Calculate
+	TransactionKindSuggestedRefund TransactionKind = "suggested_refund"
+)
+
+// TransactionStatus is the status of a transaction
+type TransactionStatus string
+
+// Statuses of a transaction
+const (
+	TransactionStatusPending TransactionStatus = "pending"
+	TransactionStatusSuccess TransactionStatus = "success"
+	TransactionStatusFailure TransactionStatus = "failure"
+	TransactionStatusError   TransactionStatus = "error"
+)
+
+// TransactionService is an interface for interfacing with the transaction
+// endpoints of the Shoplazza API.
+type TransactionService interface {
+	List(ID, interface{}) ([]Transaction, error)
+	ListWithContext(context.Context, ID, interface{}) ([]Transaction, error)
+	Count(ID, interface{}) (int, error)
+	CountWithContext(context.Context, ID, interface{}) (int, error)
+	Get(ID, ID, interface{}) (*Transaction, error)
+	GetWithContext(context.Context, ID, ID, interface{}) (*Transaction, error)
+	Create(ID, Transaction) (*Transaction, error)
+	CreateWithContext(context.Context, ID, Transaction) (*Transaction, error)
+}
+
+// TransactionServiceOp handles communication with the transaction related
+// methods of the Shoplazza API.
+type TransactionServiceOp struct {
+	client *Client
+}
+
+// TransactionResource represents the result from the orders/X/transactions/Y endpoint
+type TransactionResource struct {
+	Transaction *Transaction `json:"transaction"`
+}
+
+// TransactionsResource represents the result from the orders/X/transactions endpoint
+type TransactionsResource struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// List transactions of an order
+func (s *TransactionServiceOp) List(orderID ID, options interface{}) ([]Transaction, error) {
+	return s.ListWithContext(context.Background(), orderID, options)
+}
+
+// ListWithContext lists transactions of an order using ctx for the request
+func (s *TransactionServiceOp) ListWithContext(ctx context.Context, orderID ID, options interface{}) ([]Transaction, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/transactions", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	resource := new(TransactionsResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Transactions, err
+}
+
+// Count transactions of an order
+func (s *TransactionServiceOp) Count(orderID ID, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), orderID, options)
+}
+
+// CountWithContext counts transactions of an order using ctx for the request
+func (s *TransactionServiceOp) CountWithContext(ctx context.Context, orderID ID, options interface{}) (int, error) {
+	if err := requireIDs(orderID); err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/transactions/count", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual transaction of an order
+func (s *TransactionServiceOp) Get(orderID, transactionID ID, options interface{}) (*Transaction, error) {
+	return s.GetWithContext(context.Background(), orderID, transactionID, options)
+}
+
+// GetWithContext gets an individual transaction of an order using ctx for
+// the request
+func (s *TransactionServiceOp) GetWithContext(ctx context.Context, orderID, transactionID ID, options interface{}) (*Transaction, error) {
+	if err := requireIDs(orderID, transactionID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/transactions/%s", s.client.pathPrefix, ordersBasePath, orderID.escape(), transactionID.escape())
+	resource := new(TransactionResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Transaction, err
+}
+
+// Create a transaction for an order. The Kind of the transaction selects
+// the action: capture or void an authorization by setting its ID as the
+// ParentID, or record a manual payment of an offline order with a sale.
+func (s *TransactionServiceOp) Create(orderID ID, transaction Transaction) (*Transaction, error) {
+	return s.CreateWithContext(context.Background(), orderID, transaction)
+}
+
+// CreateWithContext creates a transaction for an order using ctx for the
+// request
+func (s *TransactionServiceOp) CreateWithContext(ctx context.Context, orderID ID, transaction Transaction) (*Transaction, error) {
+	if err := requireIDs(orderID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/transactions", s.client.pathPrefix, ordersBasePath, orderID.escape())
+	wrappedData := TransactionResource{Transaction: &transaction}
+	resource := new(TransactionResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Transaction, err
+}
@@ -0,0 +1,62 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTransactionCreate(t *testing.T) {
+	var sent sentRequest
+	client := newRecordingClient(t, `{"transaction":{"id":"t2","order_id":"1","kind":"capture","status":"success","amount":"10.00","parent_id":"t1"}}`, &sent)
+
+	amount := decimal.RequireFromString("10.00")
+	transaction, err := client.Transaction.Create("1", Transaction{
+		Kind:     TransactionKindCapture,
+		Amount:   &amount,
+		ParentID: "t1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Method != "POST" || sent.Path != "/openapi/orders/1/transactions" {
+		t.Errorf("request = %s %s, want POST /openapi/orders/1/transactions", sent.Method, sent.Path)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(sent.Body["transaction"], &body); err != nil || len(sent.Body) != 1 {
+		t.Fatalf("body = %v, want a single transaction object", sent.Body)
+	}
+	if string(body["kind"]) != `"capture"` || string(body["amount"]) != `"10"` || string(body["parent_id"]) != `"t1"` {
+		t.Errorf("transaction sent = %v, want the capture of t1", body)
+	}
+
+	if transaction.ID != "t2" || transaction.Status != TransactionStatusSuccess || transaction.Kind != TransactionKindCapture {
+		t.Errorf("transaction = %+v, want the successful capture t2", transaction)
+	}
+}
+
+func TestTransactionListAndCount(t *testing.T) {
+	var sent sentRequest
+	client := newRecordingClient(t, `{"transactions":[{"id":"t1","kind":"authorization","status":"success"},{"id":"t2","kind":"void","status":"pending"}]}`, &sent)
+
+	transactions, err := client.Transaction.List("1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Method != "GET" || sent.Path != "/openapi/orders/1/transactions" {
+		t.Errorf("request = %s %s, want GET /openapi/orders/1/transactions", sent.Method, sent.Path)
+	}
+	if len(transactions) != 2 || transactions[0].Kind != TransactionKindAuthorization || transactions[1].Status != TransactionStatusPending {
+		t.Errorf("transactions = %+v", transactions)
+	}
+
+	client = newRecordingClient(t, `{"count":2}`, &sent)
+	if count, err := client.Transaction.Count("1", nil); err != nil || count != 2 {
+		t.Errorf("Count = %d, %v, want 2", count, err)
+	}
+	if sent.Path != "/openapi/orders/1/transactions/count" {
+		t.Errorf("path = %s, want /openapi/orders/1/transactions/count", sent.Path)
+	}
+}
@@ -2,6 +2,7 @@ package goshoplazza
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -33,24 +34,48 @@ func ShopBaseUrl(name string) string {
 	return fmt.Sprintf("https://%s", name)
 }
 
-// Return the prefix for a metafield path
+// Return the prefix for a metafield path of the unversioned API
+//
+// Deprecated: use Client.MetafieldPathPrefix, which honours the api version
+// of the client.
 func MetafieldPathPrefix(resource string, resourceID int64) string {
+	return metafieldPathPrefix(defaultApiPathPrefix, resource, ID(strconv.FormatInt(resourceID, 10)))
+}
+
+// Return the prefix for a fulfillment path of the unversioned API
+//
+// Deprecated: use Client.FulfillmentPathPrefix, which honours the api
+// version of the client.
+func FulfillmentPathPrefix(resource string, resourceID string) string {
+	return fulfillmentPathPrefix(defaultApiPathPrefix, resource, ID(resourceID))
+}
+
+// Return the prefix for a metafield path
+func (c *Client) MetafieldPathPrefix(resource string, resourceID ID) string {
+	return metafieldPathPrefix(c.pathPrefix, resource, resourceID)
+}
+
+// Return the prefix for a fulfillment path
+func (c *Client) FulfillmentPathPrefix(resource string, resourceID ID) string {
+	return fulfillmentPathPrefix(c.pathPrefix, resource, resourceID)
+}
+
+func metafieldPathPrefix(pathPrefix, resource string, resourceID ID) string {
 	var prefix string
 	if resource == "" {
-		prefix = fmt.Sprintf("%s/metafields", globalApiPathPrefix)
+		prefix = fmt.Sprintf("%s/metafields", pathPrefix)
 	} else {
-		prefix = fmt.Sprintf("%s/%s/%d/metafields", globalApiPathPrefix, resource, resourceID)
+		prefix = fmt.Sprintf("%s/%s/%s/metafields", pathPrefix, resource, resourceID.escape())
 	}
 	return prefix
 }
 
-// Return the prefix for a fulfillment path
-func FulfillmentPathPrefix(resource string, resourceID string) string {
+func fulfillmentPathPrefix(pathPrefix, resource string, resourceID ID) string {
 	var prefix string
 	if resource == "" {
-		prefix = fmt.Sprintf("%s/fulfillments", globalApiPathPrefix)
+		prefix = fmt.Sprintf("%s/fulfillments", pathPrefix)
 	} else {
-		prefix = fmt.Sprintf("%s/%s/%s/fulfillments", globalApiPathPrefix, resource, resourceID)
+		prefix = fmt.Sprintf("%s/%s/%s/fulfillments", pathPrefix, resource, resourceID.escape())
 	}
 	return prefix
 }
@@ -1,6 +1,7 @@
 package goshoplazza
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,12 +14,18 @@ const variantsBasePath = "variants"
 // of the Shopify API.
 // See https://help.shopify.com/api/reference/product_variant
 type VariantService interface {
-	List(int64, interface{}) ([]Variant, error)
-	Count(int64, interface{}) (int, error)
-	Get(int64, interface{}) (*Variant, error)
-	Create(int64, Variant) (*Variant, error)
+	List(ID, interface{}) ([]Variant, error)
+	ListWithContext(context.Context, ID, interface{}) ([]Variant, error)
+	Count(ID, interface{}) (int, error)
+	CountWithContext(context.Context, ID, interface{}) (int, error)
+	Get(ID, interface{}) (*Variant, error)
+	GetWithContext(context.Context, ID, interface{}) (*Variant, error)
+	Create(ID, Variant) (*Variant, error)
+	CreateWithContext(context.Context, ID, Variant) (*Variant, error)
 	Update(Variant) (*Variant, error)
-	Delete(int64, int64) error
+	UpdateWithContext(context.Context, Variant) (*Variant, error)
+	Delete(ID, ID) error
+	DeleteWithContext(context.Context, ID, ID) error
 }
 
 // VariantServiceOp handles communication with the variant related methods of
@@ -29,11 +36,11 @@ type VariantServiceOp struct {
 
 // Variant represents a Shopify variant
 type Variant struct {
-	ID                string           `json:"id,omitempty"`
-	ProductID         string           `json:"product_id,omitempty"`
+	ID                ID               `json:"id,omitempty"`
+	ProductID         ID               `json:"product_id,omitempty"`
 	Title             string           `json:"title,omitempty"`
 	Sku               string           `json:"sku,omitempty"`
-	Position          int              `json:"position,omitempty"`
+	Position          FlexInt          `json:"position,omitempty"`
 	Price             *decimal.Decimal `json:"price,omitempty"`
 	CompareAtPrice    *decimal.Decimal `json:"compare_at_price,omitempty"`
 	Option1           string           `json:"option1,omitempty"`
@@ -43,8 +50,8 @@ type Variant struct {
 	UpdatedAt         *time.Time       `json:"updated_at,omitempty"`
 	Image             Image            `json:"image,omitempty"`
 	Barcode           string           `json:"barcode,omitempty"`
-	InventoryQuantity int              `json:"inventory_quantity,omitempty"`
-	Weight            *decimal.Decimal `json:"weight,omitempty"`
+	InventoryQuantity FlexInt          `json:"inventory_quantity,omitempty"`
+	Weight            *FlexDecimal     `json:"weight,omitempty"`
 	WeightUnit        string           `json:"weight_unit,omitempty"`
 	Note              string           `json:"note,omitempty"`
 }
@@ -60,46 +67,94 @@ type VariantsResource struct {
 }
 
 // List variants
-func (s *VariantServiceOp) List(productID int64, options interface{}) ([]Variant, error) {
-	path := fmt.Sprintf("%s/%s/%d/variants.json", globalApiPathPrefix, productsBasePath, productID)
+func (s *VariantServiceOp) List(productID ID, options interface{}) ([]Variant, error) {
+	return s.ListWithContext(context.Background(), productID, options)
+}
+
+// ListWithContext lists variants using ctx for the request
+func (s *VariantServiceOp) ListWithContext(ctx context.Context, productID ID, options interface{}) ([]Variant, error) {
+	if err := requireIDs(productID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/variants.json", s.client.pathPrefix, productsBasePath, productID.escape())
 	resource := new(VariantsResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Variants, err
 }
 
 // Count variants
-func (s *VariantServiceOp) Count(productID int64, options interface{}) (int, error) {
-	path := fmt.Sprintf("%s/%s/%d/variants/count.json", globalApiPathPrefix, productsBasePath, productID)
-	return s.client.Count(path, options)
+func (s *VariantServiceOp) Count(productID ID, options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), productID, options)
+}
+
+// CountWithContext counts variants using ctx for the request
+func (s *VariantServiceOp) CountWithContext(ctx context.Context, productID ID, options interface{}) (int, error) {
+	if err := requireIDs(productID); err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/variants/count.json", s.client.pathPrefix, productsBasePath, productID.escape())
+	return s.client.CountWithContext(ctx, path, options)
 }
 
 // Get individual variant
-func (s *VariantServiceOp) Get(variantID int64, options interface{}) (*Variant, error) {
-	path := fmt.Sprintf("%s/%s/%d.json", globalApiPathPrefix, variantsBasePath, variantID)
+func (s *VariantServiceOp) Get(variantID ID, options interface{}) (*Variant, error) {
+	return s.GetWithContext(context.Background(), variantID, options)
+}
+
+// GetWithContext gets an individual variant using ctx for the request
+func (s *VariantServiceOp) GetWithContext(ctx context.Context, variantID ID, options interface{}) (*Variant, error) {
+	if err := requireIDs(variantID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s.json", s.client.pathPrefix, variantsBasePath, variantID.escape())
 	resource := new(VariantResource)
-	err := s.client.Get(path, resource, options)
+	err := s.client.GetWithContext(ctx, path, resource, options)
 	return resource.Variant, err
 }
 
 // Create a new variant
-func (s *VariantServiceOp) Create(productID int64, variant Variant) (*Variant, error) {
-	path := fmt.Sprintf("%s/%s/%d/variants.json", globalApiPathPrefix, productsBasePath, productID)
+func (s *VariantServiceOp) Create(productID ID, variant Variant) (*Variant, error) {
+	return s.CreateWithContext(context.Background(), productID, variant)
+}
+
+// CreateWithContext creates a new variant using ctx for the request
+func (s *VariantServiceOp) CreateWithContext(ctx context.Context, productID ID, variant Variant) (*Variant, error) {
+	if err := requireIDs(productID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s/variants.json", s.client.pathPrefix, productsBasePath, productID.escape())
 	wrappedData := VariantResource{Variant: &variant}
 	resource := new(VariantResource)
-	err := s.client.Post(path, wrappedData, resource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
 	return resource.Variant, err
 }
 
 // Update existing variant
 func (s *VariantServiceOp) Update(variant Variant) (*Variant, error) {
-	path := fmt.Sprintf("%s/%s/%d.json", globalApiPathPrefix, variantsBasePath, variant.ID)
+	return s.UpdateWithContext(context.Background(), variant)
+}
+
+// UpdateWithContext updates an existing variant using ctx for the request
+func (s *VariantServiceOp) UpdateWithContext(ctx context.Context, variant Variant) (*Variant, error) {
+	if err := requireIDs(variant.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s.json", s.client.pathPrefix, variantsBasePath, variant.ID.escape())
 	wrappedData := VariantResource{Variant: &variant}
 	resource := new(VariantResource)
-	err := s.client.Put(path, wrappedData, resource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
 	return resource.Variant, err
 }
 
 // Delete an existing product
-func (s *VariantServiceOp) Delete(productID int64, variantID int64) error {
-	return s.client.Delete(fmt.Sprintf("%s/%s/%d/variants/%d.json", globalApiPathPrefix, productsBasePath, productID, variantID))
+func (s *VariantServiceOp) Delete(productID ID, variantID ID) error {
+	return s.DeleteWithContext(context.Background(), productID, variantID)
+}
+
+// DeleteWithContext deletes an existing variant using ctx for the request
+func (s *VariantServiceOp) DeleteWithContext(ctx context.Context, productID ID, variantID ID) error {
+	if err := requireIDs(productID, variantID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s/variants/%s.json", s.client.pathPrefix, productsBasePath, productID.escape(), variantID.escape()))
 }
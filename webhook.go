@@ -0,0 +1,225 @@
+package goshoplazza
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const webhooksBasePath = "webhooks"
+
+// WebhookService is an interface for interfacing with the webhook endpoints of
+// the Shoplazza API.
+type WebhookService interface {
+	List(interface{}) ([]Webhook, error)
+	ListWithContext(context.Context, interface{}) ([]Webhook, error)
+	Count(interface{}) (int, error)
+	CountWithContext(context.Context, interface{}) (int, error)
+	Get(ID, interface{}) (*Webhook, error)
+	GetWithContext(context.Context, ID, interface{}) (*Webhook, error)
+	Create(Webhook) (*Webhook, error)
+	CreateWithContext(context.Context, Webhook) (*Webhook, error)
+	Update(Webhook) (*Webhook, error)
+	UpdateWithContext(context.Context, Webhook) (*Webhook, error)
+	Delete(ID) error
+	DeleteWithContext(context.Context, ID) error
+	Reconcile([]Webhook) (*WebhookReconciliation, error)
+	ReconcileWithContext(context.Context, []Webhook) (*WebhookReconciliation, error)
+}
+
+// WebhookServiceOp handles communication with the webhook-related methods of
+// the Shoplazza API.
+type WebhookServiceOp struct {
+	client *Client
+}
+
+// Webhook represents a Shoplazza webhook subscription
+type Webhook struct {
+	ID        ID         `json:"id,omitempty"`
+	Address   string     `json:"address,omitempty"`
+	Topic     string     `json:"topic,omitempty"`
+	Format    string     `json:"format,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// WebhookOptions can be used for filtering webhooks on a List request.
+type WebhookOptions struct {
+	Address string `url:"address,omitempty"`
+	Topic   string `url:"topic,omitempty"`
+}
+
+// WebhookResource represents the result from the webhooks/X endpoint
+type WebhookResource struct {
+	Webhook *Webhook `json:"webhook"`
+}
+
+// WebhooksResource represents the result from the webhooks endpoint
+type WebhooksResource struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// WebhookReconciliation lists the changes made by Reconcile
+type WebhookReconciliation struct {
+	Created []Webhook
+	Updated []Webhook
+	Deleted []Webhook
+}
+
+// List webhooks
+func (s *WebhookServiceOp) List(options interface{}) ([]Webhook, error) {
+	return s.ListWithContext(context.Background(), options)
+}
+
+// ListWithContext lists webhooks using ctx for the request
+func (s *WebhookServiceOp) ListWithContext(ctx context.Context, options interface{}) ([]Webhook, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, webhooksBasePath)
+	resource := new(WebhooksResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Webhooks, err
+}
+
+// Count webhooks
+func (s *WebhookServiceOp) Count(options interface{}) (int, error) {
+	return s.CountWithContext(context.Background(), options)
+}
+
+// CountWithContext counts webhooks using ctx for the request
+func (s *WebhookServiceOp) CountWithContext(ctx context.Context, options interface{}) (int, error) {
+	path := fmt.Sprintf("%s/%s/count", s.client.pathPrefix, webhooksBasePath)
+	return s.client.CountWithContext(ctx, path, options)
+}
+
+// Get individual webhook
+func (s *WebhookServiceOp) Get(webhookID ID, options interface{}) (*Webhook, error) {
+	return s.GetWithContext(context.Background(), webhookID, options)
+}
+
+// GetWithContext gets an individual webhook using ctx for the request
+func (s *WebhookServiceOp) GetWithContext(ctx context.Context, webhookID ID, options interface{}) (*Webhook, error) {
+	if err := requireIDs(webhookID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, webhooksBasePath, webhookID.escape())
+	resource := new(WebhookResource)
+	err := s.client.GetWithContext(ctx, path, resource, options)
+	return resource.Webhook, err
+}
+
+// Create a new webhook
+func (s *WebhookServiceOp) Create(webhook Webhook) (*Webhook, error) {
+	return s.CreateWithContext(context.Background(), webhook)
+}
+
+// CreateWithContext creates a new webhook using ctx for the request
+func (s *WebhookServiceOp) CreateWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	path := fmt.Sprintf("%s/%s", s.client.pathPrefix, webhooksBasePath)
+	wrappedData := WebhookResource{Webhook: &webhook}
+	resource := new(WebhookResource)
+	err := s.client.PostWithContext(ctx, path, wrappedData, resource)
+	return resource.Webhook, err
+}
+
+// Update an existing webhook
+func (s *WebhookServiceOp) Update(webhook Webhook) (*Webhook, error) {
+	return s.UpdateWithContext(context.Background(), webhook)
+}
+
+// UpdateWithContext updates an existing webhook using ctx for the request
+func (s *WebhookServiceOp) UpdateWithContext(ctx context.Context, webhook Webhook) (*Webhook, error) {
+	if err := requireIDs(webhook.ID); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, webhooksBasePath, webhook.ID.escape())
+	wrappedData := WebhookResource{Webhook: &webhook}
+	resource := new(WebhookResource)
+	err := s.client.PutWithContext(ctx, path, wrappedData, resource)
+	return resource.Webhook, err
+}
+
+// Delete an existing webhook
+func (s *WebhookServiceOp) Delete(webhookID ID) error {
+	return s.DeleteWithContext(context.Background(), webhookID)
+}
+
+// DeleteWithContext deletes an existing webhook using ctx for the request
+func (s *WebhookServiceOp) DeleteWithContext(ctx context.Context, webhookID ID) error {
+	if err := requireIDs(webhookID); err != nil {
+		return err
+	}
+	return s.client.DeleteWithContext(ctx, fmt.Sprintf("%s/%s/%s", s.client.pathPrefix, webhooksBasePath, webhookID.escape()))
+}
+
+// Reconcile converges the webhook subscriptions of the shop to the desired
+// set, one subscription per topic. Missing topics are created, subscriptions
+// with a different address or format are updated, and subscriptions for
+// topics not in desired are deleted. Running it again with the same desired
+// set makes no changes.
+func (s *WebhookServiceOp) Reconcile(desired []Webhook) (*WebhookReconciliation, error) {
+	return s.ReconcileWithContext(context.Background(), desired)
+}
+
+// ReconcileWithContext reconciles webhooks using ctx for the requests
+func (s *WebhookServiceOp) ReconcileWithContext(ctx context.Context, desired []Webhook) (*WebhookReconciliation, error) {
+	it := newIterator(ctx, 1, "", func(ctx context.Context, page int, sinceID ID) ([]Webhook, error) {
+		return s.ListWithContext(ctx, ListOptions{Page: page, Limit: 250})
+	}, func(webhook Webhook) ID { return webhook.ID })
+	existing, err := collect(it)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]Webhook, len(desired))
+	for _, webhook := range desired {
+		wanted[webhook.Topic] = webhook
+	}
+
+	result := new(WebhookReconciliation)
+	kept := make(map[string]bool, len(desired))
+	for _, current := range existing {
+		want, ok := wanted[current.Topic]
+		if !ok || kept[current.Topic] {
+			// Not desired, or a duplicate subscription for the same topic
+			if err := s.DeleteWithContext(ctx, current.ID); err != nil {
+				return result, err
+			}
+			result.Deleted = append(result.Deleted, current)
+			continue
+		}
+
+		kept[current.Topic] = true
+		if current.Address == want.Address && (want.Format == "" || current.Format == want.Format) {
+			continue
+		}
+
+		want.ID = current.ID
+		updated, err := s.UpdateWithContext(ctx, want)
+		if err != nil {
+			return result, err
+		}
+		if updated == nil {
+			return result, fmt.Errorf("webhook %s for %s: empty update response", current.ID, current.Topic)
+		}
+		result.Updated = append(result.Updated, *updated)
+	}
+
+	for _, webhook := range desired {
+		if kept[webhook.Topic] {
+			continue
+		}
+		kept[webhook.Topic] = true
+
+		want := wanted[webhook.Topic]
+		want.ID = ""
+		created, err := s.CreateWithContext(ctx, want)
+		if err != nil {
+			return result, err
+		}
+		if created == nil {
+			return result, fmt.Errorf("webhook for %s: empty create response", want.Topic)
+		}
+		result.Created = append(result.Created, *created)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package goshoplazza
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestWebhookReconcileAllPages(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			var webhooks []Webhook
+			if page == 1 {
+				for i := 1; i <= 250; i++ {
+					webhooks = append(webhooks, Webhook{ID: ID(strconv.Itoa(i)), Topic: fmt.Sprintf("stale/%d", i), Address: "https://example.com"})
+				}
+			} else if page == 2 {
+				webhooks = []Webhook{{ID: "251", Topic: TopicOrdersCreate, Address: "https://example.com"}}
+			}
+			json.NewEncoder(w).Encode(WebhooksResource{Webhooks: webhooks})
+		case http.MethodPost:
+			w.Write([]byte(`{"webhook":{"id":"300","topic":"orders/paid","address":"https://example.com"}}`))
+		}
+	})
+	mux.HandleFunc("/openapi/webhooks/", func(w http.ResponseWriter, r *http.Request) {
+		deleted = append(deleted, r.URL.Path)
+		w.Write([]byte(`{}`))
+	})
+	client := newTestClient(t, mux)
+
+	result, err := client.Webhook.Reconcile([]Webhook{
+		{Topic: TopicOrdersCreate, Address: "https://example.com"},
+		{Topic: TopicOrdersPaid, Address: "https://example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 250 || len(deleted) != 250 {
+		t.Errorf("deleted %d webhooks, want 250", len(result.Deleted))
+	}
+	if len(result.Created) != 1 || result.Created[0].Topic != TopicOrdersPaid {
+		t.Errorf("created %v, want only %s, the subscription on page 2 is kept", result.Created, TopicOrdersPaid)
+	}
+}
+
+func TestWebhookReconcileEmptyResponse(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"webhooks":[]}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	_, err := client.Webhook.Reconcile([]Webhook{{Topic: TopicOrdersCreate, Address: "https://example.com"}})
+	if err == nil {
+		t.Error("Reconcile with an empty create response returned no error")
+	}
+}
@@ -0,0 +1,286 @@
+package goshoplazza
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Headers sent by Shoplazza with every webhook notification
+const (
+	WebhookHmacHeader       = "X-Shoplazza-Hmac-Sha256"
+	WebhookTopicHeader      = "X-Shoplazza-Topic"
+	WebhookShopDomainHeader = "X-Shoplazza-Shop-Domain"
+	WebhookIDHeader         = "X-Shoplazza-Webhook-Id"
+)
+
+// Webhook topics
+const (
+	TopicOrdersCreate       = "orders/create"
+	TopicOrdersUpdated      = "orders/updated"
+	TopicOrdersPaid         = "orders/paid"
+	TopicOrdersCancelled    = "orders/cancelled"
+	TopicOrdersFulfilled    = "orders/fulfilled"
+	TopicOrdersDelete       = "orders/delete"
+	TopicProductsCreate     = "products/create"
+	TopicProductsUpdate     = "products/update"
+	TopicProductsDelete     = "products/delete"
+	TopicFulfillmentsCreate = "fulfillments/create"
+	TopicFulfillmentsUpdate = "fulfillments/update"
+	TopicAppUninstalled     = "app/uninstalled"
+)
+
+const (
+	defaultWebhookMaxBodyBytes = 5 << 20
+	defaultWebhookReplayWindow = 24 * time.Hour
+)
+
+// WebhookEvent is a verified webhook notification. Only Body is covered by
+// the signature, ID, Topic and ShopDomain are copied from unsigned headers.
+type WebhookEvent struct {
+	ID         string
+	Topic      string
+	ShopDomain string
+	Body       []byte
+}
+
+// WebhookFunc handles a webhook notification. Returning an error responds
+// with a server error, so that Shoplazza delivers the notification again.
+type WebhookFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookHandler is an http.Handler verifying webhook notifications against
+// the app secret and dispatching them to the callbacks registered for their
+// topic.
+//
+// Notifications that fail verification are rejected with 401. Notifications
+// without a registered callback, or whose body was already handled for the
+// same topic within the replay window, are acknowledged without dispatching
+// them.
+type WebhookHandler struct {
+	app App
+
+	// Maximum accepted body size, defaults to 5MB
+	MaxBodyBytes int64
+
+	// How long handled notifications are remembered to drop replays,
+	// defaults to 24 hours
+	ReplayWindow time.Duration
+
+	mu       sync.Mutex
+	handlers map[string]WebhookFunc
+
+	// Handled notifications are kept in two buckets, seen is started at
+	// seenSince and rotated into prevSeen once it spans a replay window.
+	seen      map[string]struct{}
+	prevSeen  map[string]struct{}
+	seenSince time.Time
+}
+
+// NewWebhookHandler returns a webhook handler verifying notifications with
+// the app's ApiSecret.
+func (app App) NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{
+		app:      app,
+		handlers: make(map[string]WebhookFunc),
+	}
+}
+
+// webhookDecodingError is returned by typed callbacks when the payload does
+// not match the topic's resource.
+type webhookDecodingError struct {
+	err error
+}
+
+func (e webhookDecodingError) Error() string {
+	return e.err.Error()
+}
+
+// Handle registers fn for the given topic, replacing any previous callback
+func (h *WebhookHandler) Handle(topic string, fn WebhookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[topic] = fn
+}
+
+// HandleOrder registers fn for an order topic, decoding the payload into an
+// Order.
+func (h *WebhookHandler) HandleOrder(topic string, fn func(ctx context.Context, event WebhookEvent, order *Order) error) {
+	h.Handle(topic, func(ctx context.Context, event WebhookEvent) error {
+		order := new(Order)
+		if err := json.Unmarshal(event.Body, order); err != nil {
+			return webhookDecodingError{err}
+		}
+		return fn(ctx, event, order)
+	})
+}
+
+// HandleProduct registers fn for a product topic, decoding the payload into
+// a Product.
+func (h *WebhookHandler) HandleProduct(topic string, fn func(ctx context.Context, event WebhookEvent, product *Product) error) {
+	h.Handle(topic, func(ctx context.Context, event WebhookEvent) error {
+		product := new(Product)
+		if err := json.Unmarshal(event.Body, product); err != nil {
+			return webhookDecodingError{err}
+		}
+		return fn(ctx, event, product)
+	})
+}
+
+// HandleFulfillment registers fn for a fulfillment topic, decoding the
+// payload into a Fulfillment.
+func (h *WebhookHandler) HandleFulfillment(topic string, fn func(ctx context.Context, event WebhookEvent, fulfillment *Fulfillment) error) {
+	h.Handle(topic, func(ctx context.Context, event WebhookEvent) error {
+		fulfillment := new(Fulfillment)
+		if err := json.Unmarshal(event.Body, fulfillment); err != nil {
+			return webhookDecodingError{err}
+		}
+		return fn(ctx, event, fulfillment)
+	})
+}
+
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultWebhookMaxBodyBytes
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		http.Error(w, "could not read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > maxBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	signature := r.Header.Get(WebhookHmacHeader)
+	if !h.app.VerifyWebhookMessage(body, signature) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	event := WebhookEvent{
+		ID:         r.Header.Get(WebhookIDHeader),
+		Topic:      r.Header.Get(WebhookTopicHeader),
+		ShopDomain: r.Header.Get(WebhookShopDomainHeader),
+		Body:       body,
+	}
+
+	h.mu.Lock()
+	fn := h.handlers[event.Topic]
+	h.mu.Unlock()
+	if fn == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// The headers are not signed, a replay could change the webhook id at
+	// will. Key on the topic, dispatching the body to a different callback,
+	// and the signed body, so a replay is recognized anyway while different
+	// topics carrying the same payload are all dispatched.
+	sum := sha256.Sum256(body)
+	replayKey := event.Topic + ":" + hex.EncodeToString(sum[:])
+	if !h.markSeen(replayKey) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	err = fn(r.Context(), event)
+	if err != nil {
+		// Allow Shoplazza to deliver the notification again
+		h.forget(replayKey)
+
+		var decodingErr webhookDecodingError
+		if errors.As(err, &decodingErr) {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// markSeen records key as handled, it returns false if key was already
+// handled within the replay window. Keys are remembered for at least one
+// and at most two replay windows.
+func (h *WebhookHandler) markSeen(key string) bool {
+	window := h.ReplayWindow
+	if window <= 0 {
+		window = defaultWebhookReplayWindow
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if h.seen == nil || now.Sub(h.seenSince) >= window {
+		if h.seen != nil && now.Sub(h.seenSince) < 2*window {
+			h.prevSeen = h.seen
+		} else {
+			h.prevSeen = nil
+		}
+		h.seen = make(map[string]struct{})
+		h.seenSince = now
+	}
+
+	if _, ok := h.seen[key]; ok {
+		return false
+	}
+	if _, ok := h.prevSeen[key]; ok {
+		return false
+	}
+	h.seen[key] = struct{}{}
+	return true
+}
+
+func (h *WebhookHandler) forget(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+	delete(h.prevSeen, key)
+}
+
+// VerifyWebhookMessage reports whether signature is the base64 encoded hmac
+// of body, signed with the app's ApiSecret.
+func (app App) VerifyWebhookMessage(body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+	expectedMAC, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(app.ApiSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expectedMAC)
+}
+
+// VerifyWebhookRequest verifies the signature of a webhook request. The
+// request body is restored so it can be read again.
+func (app App) VerifyWebhookRequest(r *http.Request) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return app.VerifyWebhookMessage(body, r.Header.Get(WebhookHmacHeader))
+}
@@ -0,0 +1,148 @@
+package goshoplazza
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signWebhook(body string) string {
+	mac := hmac.New(sha256.New, []byte(testApp.ApiSecret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func postWebhook(h http.Handler, id, topic, body, signature string) int {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	r.Header.Set(WebhookHmacHeader, signature)
+	r.Header.Set(WebhookTopicHeader, topic)
+	r.Header.Set(WebhookIDHeader, id)
+	r.Header.Set(WebhookShopDomainHeader, "theshop.myshoplaza.com")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestWebhookHandler(t *testing.T) {
+	body := `{"id":"1","name":"#1001"}`
+	signature := signWebhook(body)
+
+	cases := []struct {
+		name      string
+		id        string
+		topic     string
+		body      string
+		signature string
+		wantCode  int
+		wantCalls int
+	}{
+		{"valid signature", "a", TopicOrdersCreate, body, signature, http.StatusOK, 1},
+		{"bad signature", "a", TopicOrdersCreate, body, signWebhook(`{"id":"2"}`), http.StatusUnauthorized, 0},
+		{"tampered body", "a", TopicOrdersCreate, `{"id":"2","name":"#1001"}`, signature, http.StatusUnauthorized, 0},
+		{"unknown topic", "a", TopicProductsCreate, body, signature, http.StatusOK, 0},
+	}
+	for _, c := range cases {
+		h := testApp.NewWebhookHandler()
+		calls := 0
+		h.HandleOrder(TopicOrdersCreate, func(ctx context.Context, event WebhookEvent, order *Order) error {
+			calls++
+			if event.ID != c.id || order.Name != "#1001" {
+				t.Errorf("%s: event %s, order %q", c.name, event.ID, order.Name)
+			}
+			return nil
+		})
+		if code := postWebhook(h, c.id, c.topic, c.body, c.signature); code != c.wantCode {
+			t.Errorf("%s: status = %d, want %d", c.name, code, c.wantCode)
+		}
+		if calls != c.wantCalls {
+			t.Errorf("%s: %d calls, want %d", c.name, calls, c.wantCalls)
+		}
+	}
+}
+
+func TestWebhookHandlerReplay(t *testing.T) {
+	body := `{"id":"1","name":"#1001"}`
+	signature := signWebhook(body)
+
+	h := testApp.NewWebhookHandler()
+	calls := map[string]int{}
+	for _, topic := range []string{TopicOrdersCreate, TopicOrdersCancelled} {
+		topic := topic
+		h.Handle(topic, func(ctx context.Context, event WebhookEvent) error {
+			calls[topic]++
+			return nil
+		})
+	}
+
+	postWebhook(h, "a", TopicOrdersCreate, body, signature)
+	if code := postWebhook(h, "a", TopicOrdersCreate, body, signature); code != http.StatusOK {
+		t.Errorf("replay with the same id: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := postWebhook(h, "b", TopicOrdersCreate, body, signature); code != http.StatusOK {
+		t.Errorf("replay with a changed id: status = %d, want %d", code, http.StatusOK)
+	}
+
+	// Different topics may carry the same payload, e.g. an order updated then
+	// cancelled without other change
+	if code := postWebhook(h, "c", TopicOrdersCancelled, body, signature); code != http.StatusOK {
+		t.Errorf("same body for another topic: status = %d, want %d", code, http.StatusOK)
+	}
+	postWebhook(h, "d", TopicOrdersCancelled, body, signature)
+
+	if calls[TopicOrdersCreate] != 1 || calls[TopicOrdersCancelled] != 1 {
+		t.Errorf("calls = %v, want a single dispatch per topic", calls)
+	}
+}
+
+func TestWebhookHandlerRetryAfterError(t *testing.T) {
+	body := `{"id":"1"}`
+	signature := signWebhook(body)
+
+	h := testApp.NewWebhookHandler()
+	calls := 0
+	h.Handle(TopicOrdersCreate, func(ctx context.Context, event WebhookEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	if code := postWebhook(h, "a", TopicOrdersCreate, body, signature); code != http.StatusInternalServerError {
+		t.Errorf("failed delivery: status = %d, want %d", code, http.StatusInternalServerError)
+	}
+	if code := postWebhook(h, "a", TopicOrdersCreate, body, signature); code != http.StatusOK {
+		t.Errorf("redelivery: status = %d, want %d", code, http.StatusOK)
+	}
+	if calls != 2 {
+		t.Errorf("%d calls, want 2", calls)
+	}
+}
+
+func TestWebhookHandlerReplayWindow(t *testing.T) {
+	h := testApp.NewWebhookHandler()
+	h.ReplayWindow = time.Hour
+
+	if !h.markSeen("a") {
+		t.Fatal("markSeen(a) = false on first delivery")
+	}
+
+	// Rotated once, a is still within the window
+	h.seenSince = h.seenSince.Add(-90 * time.Minute)
+	if h.markSeen("a") {
+		t.Error("markSeen(a) = true after one rotation, want false")
+	}
+
+	// Rotated twice, a is beyond the window
+	h.seenSince = h.seenSince.Add(-2 * time.Hour)
+	if !h.markSeen("a") {
+		t.Error("markSeen(a) = false after the window, want true")
+	}
+}